@@ -192,10 +192,36 @@ type Operation struct {
 	AutoApprove  bool
 	Destroy      bool
 	DestroyForce bool
-	Parallelism  int
+	// ForceThreshold, if true, downgrades the max_destroy and max_changes
+	// plan guardrails from errors to warnings.
+	ForceThreshold bool
+	Parallelism    int
+	ShowTimings    bool
+	ProfileDir     string // if set, write pprof profiles and a graph report here
+	// AuditLogPath, if set, is a file to which an append-only JSONL log of
+	// the resource operations performed during an apply is written. An http
+	// or https URL ships the records to that endpoint instead.
+	AuditLogPath string
 	Targets      []addrs.Targetable
 	Variables    map[string]UnparsedVariableValue
 
+	// TraceExpr, if non-empty, names a resource attribute whose derivation
+	// should be reported after planning. Sensitive values in the report are
+	// redacted unless TraceExprShowSensitive is also set.
+	TraceExpr              string
+	TraceExprShowSensitive bool
+
+	// ExplainUnknowns, if true, reports after planning which upstream
+	// reference caused each planned "(known after apply)" value.
+	ExplainUnknowns bool
+
+	// StrictVariables causes values supplied for undeclared root module
+	// variables to be errors rather than warnings or silently ignored, and
+	// disables interactive prompting for unset required variables so that
+	// they are reported as errors instead. This helps catch mistyped
+	// variable names when Terraform is run in automation.
+	StrictVariables bool
+
 	// Some operations use root module variables only opportunistically or
 	// don't need them at all. If this flag is set, the backend must treat
 	// all variables as optional and provide an unknown value for any required
@@ -221,6 +247,11 @@ type Operation struct {
 	// The duration to retry obtaining a State lock.
 	StateLockTimeout time.Duration
 
+	// StateLockQueue, if true, makes the operation wait in line for a held
+	// lock instead of failing, reporting who holds it while waiting. With a
+	// zero StateLockTimeout the wait is unbounded.
+	StateLockQueue bool
+
 	// Workspace is the name of the workspace that this operation should run
 	// in, which controls which named state is used.
 	Workspace string
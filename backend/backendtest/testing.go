@@ -1,4 +1,11 @@
-package backend
+// Package backendtest contains a conformance test suite for Terraform state
+// storage backends.
+//
+// The suite is exported (rather than living in a _test.go file) so that
+// backend implementations maintained outside this codebase can validate
+// themselves against the same requirements as the built-in backends. All of
+// the built-in backends run these same functions from their own tests.
+package backendtest
 
 import (
 	"reflect"
@@ -10,6 +17,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hcldec"
 
 	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/backend"
 	"github.com/hashicorp/terraform/configs"
 	"github.com/hashicorp/terraform/configs/hcl2shim"
 	"github.com/hashicorp/terraform/state"
@@ -20,7 +28,7 @@ import (
 
 // TestBackendConfig validates and configures the backend with the
 // given configuration.
-func TestBackendConfig(t *testing.T, b Backend, c hcl.Body) Backend {
+func TestBackendConfig(t *testing.T, b backend.Backend, c hcl.Body) backend.Backend {
 	t.Helper()
 
 	t.Logf("TestBackendConfig on %T with %#v", b, c)
@@ -71,13 +79,13 @@ func TestWrapConfig(raw map[string]interface{}) hcl.Body {
 // TestBackend will test the functionality of a Backend. The backend is
 // assumed to already be configured. This will test state functionality.
 // If the backend reports it doesn't support multi-state by returning the
-// error ErrWorkspacesNotSupported, then it will not test that.
-func TestBackendStates(t *testing.T, b Backend) {
+// error backend.ErrWorkspacesNotSupported, then it will not test that.
+func TestBackendStates(t *testing.T, b backend.Backend) {
 	t.Helper()
 
 	noDefault := false
-	if _, err := b.StateMgr(DefaultStateName); err != nil {
-		if err == ErrDefaultWorkspaceNotSupported {
+	if _, err := b.StateMgr(backend.DefaultStateName); err != nil {
+		if err == backend.ErrDefaultWorkspaceNotSupported {
 			noDefault = true
 		} else {
 			t.Fatalf("error: %v", err)
@@ -86,7 +94,7 @@ func TestBackendStates(t *testing.T, b Backend) {
 
 	workspaces, err := b.Workspaces()
 	if err != nil {
-		if err == ErrWorkspacesNotSupported {
+		if err == backend.ErrWorkspacesNotSupported {
 			t.Logf("TestBackend: workspaces not supported in %T, skipping", b)
 			return
 		}
@@ -94,7 +102,7 @@ func TestBackendStates(t *testing.T, b Backend) {
 	}
 
 	// Test it starts with only the default
-	if !noDefault && (len(workspaces) != 1 || workspaces[0] != DefaultStateName) {
+	if !noDefault && (len(workspaces) != 1 || workspaces[0] != backend.DefaultStateName) {
 		t.Fatalf("should only default to start: %#v", workspaces)
 	}
 
@@ -224,7 +232,7 @@ func TestBackendStates(t *testing.T, b Backend) {
 	}
 
 	// Verify the default state can't be deleted
-	if err := b.DeleteWorkspace(DefaultStateName); err == nil {
+	if err := b.DeleteWorkspace(backend.DefaultStateName); err == nil {
 		t.Fatal("expected error")
 	}
 
@@ -266,7 +274,7 @@ func TestBackendStates(t *testing.T, b Backend) {
 
 // TestBackendStateLocks will test the locking functionality of the remote
 // state backend.
-func TestBackendStateLocks(t *testing.T, b1, b2 Backend) {
+func TestBackendStateLocks(t *testing.T, b1, b2 backend.Backend) {
 	t.Helper()
 	testLocks(t, b1, b2, false)
 }
@@ -275,16 +283,16 @@ func TestBackendStateLocks(t *testing.T, b1, b2 Backend) {
 // type, and the lock can be unlocked using the ID reported in the error.
 // Remote state backends that support -force-unlock should call this in at
 // least one of the acceptance tests.
-func TestBackendStateForceUnlock(t *testing.T, b1, b2 Backend) {
+func TestBackendStateForceUnlock(t *testing.T, b1, b2 backend.Backend) {
 	t.Helper()
 	testLocks(t, b1, b2, true)
 }
 
-func testLocks(t *testing.T, b1, b2 Backend, testForceUnlock bool) {
+func testLocks(t *testing.T, b1, b2 backend.Backend, testForceUnlock bool) {
 	t.Helper()
 
 	// Get the default state for each
-	b1StateMgr, err := b1.StateMgr(DefaultStateName)
+	b1StateMgr, err := b1.StateMgr(backend.DefaultStateName)
 	if err != nil {
 		t.Fatalf("error: %s", err)
 	}
@@ -300,7 +308,7 @@ func testLocks(t *testing.T, b1, b2 Backend, testForceUnlock bool) {
 
 	t.Logf("TestBackend: testing state locking for %T", b1)
 
-	b2StateMgr, err := b2.StateMgr(DefaultStateName)
+	b2StateMgr, err := b2.StateMgr(backend.DefaultStateName)
 	if err != nil {
 		t.Fatalf("error: %s", err)
 	}
@@ -328,7 +336,7 @@ func testLocks(t *testing.T, b1, b2 Backend, testForceUnlock bool) {
 	// Make sure we can still get the state.State from another instance even
 	// when locked.  This should only happen when a state is loaded via the
 	// backend, and as a remote state.
-	_, err = b2.StateMgr(DefaultStateName)
+	_, err = b2.StateMgr(backend.DefaultStateName)
 	if err != nil {
 		t.Errorf("failed to read locked state from another backend instance: %s", err)
 	}
@@ -22,11 +22,13 @@ import (
 	backendGCS "github.com/hashicorp/terraform/backend/remote-state/gcs"
 	backendHTTP "github.com/hashicorp/terraform/backend/remote-state/http"
 	backendInmem "github.com/hashicorp/terraform/backend/remote-state/inmem"
+	backendKubernetes "github.com/hashicorp/terraform/backend/remote-state/kubernetes"
 	backendManta "github.com/hashicorp/terraform/backend/remote-state/manta"
 	backendOSS "github.com/hashicorp/terraform/backend/remote-state/oss"
 	backendPg "github.com/hashicorp/terraform/backend/remote-state/pg"
 	backendS3 "github.com/hashicorp/terraform/backend/remote-state/s3"
 	backendSwift "github.com/hashicorp/terraform/backend/remote-state/swift"
+	backendVault "github.com/hashicorp/terraform/backend/remote-state/vault"
 )
 
 // backends is the list of available backends. This is a global variable
@@ -64,11 +66,13 @@ func Init(services *disco.Disco) {
 		"gcs":         func() backend.Backend { return backendGCS.New() },
 		"http":        func() backend.Backend { return backendHTTP.New() },
 		"inmem":       func() backend.Backend { return backendInmem.New() },
+		"kubernetes":  func() backend.Backend { return backendKubernetes.New() },
 		"manta":       func() backend.Backend { return backendManta.New() },
 		"oss":         func() backend.Backend { return backendOSS.New() },
 		"pg":          func() backend.Backend { return backendPg.New() },
 		"s3":          func() backend.Backend { return backendS3.New() },
 		"swift":       func() backend.Backend { return backendSwift.New() },
+		"vault":       func() backend.Backend { return backendVault.New() },
 
 		// Deprecated backends.
 		"azure": func() backend.Backend {
@@ -20,6 +20,7 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/colorstring"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
 )
 
 const (
@@ -27,6 +28,11 @@ const (
 	DefaultWorkspaceFile   = "environment"
 	DefaultStateFilename   = "terraform.tfstate"
 	DefaultBackupExtension = ".backup"
+
+	// DefaultBackupRetention is how many timestamped backups are kept per
+	// workspace when a backup_dir is configured without an explicit
+	// backup_retention.
+	DefaultBackupRetention = 10
 )
 
 // Local is an implementation of EnhancedBackend that performs all operations
@@ -53,12 +59,19 @@ type Local struct {
 	// StateBackupPath is the local path where a backup file will be written.
 	// Set this to "-" to disable state backup.
 	//
+	// StateBackupDir, if non-empty, selects timestamped backups in the given
+	// directory instead of the single file at StateBackupPath, keeping the
+	// most recent StateBackupRetention of them. A non-positive retention
+	// keeps every backup.
+	//
 	// StateWorkspaceDir is the path to the folder containing data for
 	// non-default workspaces. This defaults to DefaultWorkspaceDir if not set.
-	StatePath         string
-	StateOutPath      string
-	StateBackupPath   string
-	StateWorkspaceDir string
+	StatePath            string
+	StateOutPath         string
+	StateBackupPath      string
+	StateBackupDir       string
+	StateBackupRetention int
+	StateWorkspaceDir    string
 
 	// The OverrideState* paths are set based on per-operation CLI arguments
 	// and will override what'd be built from the State* fields if non-empty.
@@ -134,6 +147,14 @@ func (b *Local) ConfigSchema() *configschema.Block {
 				Type:     cty.String,
 				Optional: true,
 			},
+			"backup_dir": {
+				Type:     cty.String,
+				Optional: true,
+			},
+			"backup_retention": {
+				Type:     cty.Number,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -169,6 +190,29 @@ func (b *Local) PrepareConfig(obj cty.Value) (cty.Value, tfdiags.Diagnostics) {
 		}
 	}
 
+	if val := obj.GetAttr("backup_dir"); !val.IsNull() {
+		p := val.AsString()
+		if p == "" {
+			diags = diags.Append(tfdiags.AttributeValue(
+				tfdiags.Error,
+				"Invalid local backup directory path",
+				`The "backup_dir" attribute value must not be empty.`,
+				cty.Path{cty.GetAttrStep{Name: "backup_dir"}},
+			))
+		}
+	}
+
+	if val := obj.GetAttr("backup_retention"); !val.IsNull() {
+		if obj.GetAttr("backup_dir").IsNull() {
+			diags = diags.Append(tfdiags.AttributeValue(
+				tfdiags.Error,
+				"Invalid backup retention",
+				`The "backup_retention" attribute requires "backup_dir" to also be set.`,
+				cty.Path{cty.GetAttrStep{Name: "backup_retention"}},
+			))
+		}
+	}
+
 	return obj, diags
 }
 
@@ -195,6 +239,24 @@ func (b *Local) Configure(obj cty.Value) tfdiags.Diagnostics {
 		b.StateWorkspaceDir = DefaultWorkspaceDir
 	}
 
+	if val := obj.GetAttr("backup_dir"); !val.IsNull() {
+		b.StateBackupDir = val.AsString()
+		b.StateBackupRetention = DefaultBackupRetention
+		if val := obj.GetAttr("backup_retention"); !val.IsNull() {
+			var keep int
+			if err := gocty.FromCtyValue(val, &keep); err != nil {
+				diags = diags.Append(tfdiags.AttributeValue(
+					tfdiags.Error,
+					"Invalid backup retention",
+					fmt.Sprintf(`The "backup_retention" attribute must be a whole number: %s.`, err),
+					cty.Path{cty.GetAttrStep{Name: "backup_retention"}},
+				))
+			} else {
+				b.StateBackupRetention = keep
+			}
+		}
+	}
+
 	return diags
 }
 
@@ -268,7 +330,17 @@ func (b *Local) StateMgr(name string) (statemgr.Full, error) {
 	log.Printf("[TRACE] backend/local: state manager for workspace %q will:\n - read initial snapshot from %s\n - write new snapshots to %s\n - create any backup at %s", name, statePath, stateOutPath, backupPath)
 
 	s := statemgr.NewFilesystemBetweenPaths(statePath, stateOutPath)
-	if backupPath != "" {
+	switch {
+	case b.StateBackupDir != "" && b.OverrideStateBackupPath == "":
+		// Keep each workspace's timestamped backups in their own
+		// subdirectory so their filenames can't collide.
+		backupDir := b.StateBackupDir
+		if name != backend.DefaultStateName {
+			backupDir = filepath.Join(backupDir, name)
+		}
+		log.Printf("[TRACE] backend/local: keeping up to %d timestamped backups in %s", b.StateBackupRetention, backupDir)
+		s.SetBackupRetention(backupDir, b.StateBackupRetention)
+	case backupPath != "":
 		s.SetBackupPath(backupPath)
 	}
 
@@ -324,9 +396,12 @@ func (b *Local) Operation(ctx context.Context, op *backend.Operation) (*backend.
 	cancelCtx, cancel := context.WithCancel(context.Background())
 	runningOp.Cancel = cancel
 
-	if op.LockState {
+	switch {
+	case op.LockState && op.StateLockQueue:
+		op.StateLocker = clistate.NewQueueLocker(stopCtx, op.StateLockTimeout, b.CLI, b.Colorize())
+	case op.LockState:
 		op.StateLocker = clistate.NewLocker(stopCtx, op.StateLockTimeout, b.CLI, b.Colorize())
-	} else {
+	default:
 		op.StateLocker = clistate.NewNoopLocker()
 	}
 
@@ -6,9 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/internal/audit"
+	"github.com/hashicorp/terraform/internal/msgcat"
 	"github.com/hashicorp/terraform/states"
 	"github.com/hashicorp/terraform/states/statefile"
 	"github.com/hashicorp/terraform/states/statemgr"
@@ -42,12 +45,41 @@ func (b *Local) opApply(
 	// Setup our count hook that keeps track of resource changes
 	countHook := new(CountHook)
 	stateHook := new(StateHook)
+	timingHook := new(TimingHook)
 	if b.ContextOpts == nil {
 		b.ContextOpts = new(terraform.ContextOpts)
 	}
 	old := b.ContextOpts.Hooks
 	defer func() { b.ContextOpts.Hooks = old }()
 	b.ContextOpts.Hooks = append(b.ContextOpts.Hooks, countHook, stateHook)
+	if op.ShowTimings {
+		b.ContextOpts.Hooks = append(b.ContextOpts.Hooks, timingHook)
+	}
+	if op.AuditLogPath != "" {
+		sink, err := audit.NewSink(op.AuditLogPath)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to open audit log",
+				fmt.Sprintf("Could not open the configured audit log: %s.", err),
+			))
+			b.ReportResult(runningOp, diags)
+			return
+		}
+		defer func() {
+			// By the time the sink is closed the apply has already
+			// finished (or failed), so a broken audit log is reported
+			// without changing the operation result.
+			if err := sink.Close(); err != nil {
+				b.ShowDiagnostics(tfdiags.Sourceless(
+					tfdiags.Warning,
+					"Failed to write audit log",
+					fmt.Sprintf("The audit log may be incomplete: %s.", err),
+				))
+			}
+		}()
+		b.ContextOpts.Hooks = append(b.ContextOpts.Hooks, &AuditHook{Sink: sink})
+	}
 
 	// Get our context
 	tfCtx, _, opState, contextDiags := b.context(op)
@@ -57,6 +89,22 @@ func (b *Local) opApply(
 		return
 	}
 
+	if op.ProfileDir != "" {
+		stopProfiling, err := startProfiling(op.ProfileDir)
+		if err != nil {
+			diags = diags.Append(err)
+			b.ReportResult(runningOp, diags)
+			return
+		}
+		defer stopProfiling()
+
+		if g, graphDiags := tfCtx.Graph(terraform.GraphTypeApply, nil); !graphDiags.HasErrors() {
+			if err := writeGraphSizeReport(op.ProfileDir, g); err != nil {
+				log.Printf("[ERROR] backend/local: failed to write graph size report: %s", err)
+			}
+		}
+	}
+
 	// Setup the state
 	runningOp.State = tfCtx.State()
 
@@ -83,6 +131,15 @@ func (b *Local) opApply(
 			return
 		}
 
+		// Enforce any plan guardrails from the configuration before we
+		// consider applying the plan.
+		limitDiags := checkPlanLimits(tfCtx.Config(), plan, op.ForceThreshold)
+		diags = diags.Append(limitDiags)
+		if limitDiags.HasErrors() {
+			b.ReportResult(runningOp, diags)
+			return
+		}
+
 		trivialPlan := plan.Changes.Empty()
 		hasUI := op.UIOut != nil && op.UIIn != nil
 		mustConfirm := hasUI && ((op.Destroy && (!op.DestroyForce && !op.AutoApprove)) || (!op.Destroy && !op.AutoApprove && !trivialPlan))
@@ -90,20 +147,20 @@ func (b *Local) opApply(
 			var desc, query string
 			if op.Destroy {
 				if op.Workspace != "default" {
-					query = "Do you really want to destroy all resources in workspace \"" + op.Workspace + "\"?"
+					query = fmt.Sprintf(msgcat.T("Do you really want to destroy all resources in workspace %q?"), op.Workspace)
 				} else {
-					query = "Do you really want to destroy all resources?"
+					query = msgcat.T("Do you really want to destroy all resources?")
 				}
-				desc = "Terraform will destroy all your managed infrastructure, as shown above.\n" +
-					"There is no undo. Only 'yes' will be accepted to confirm."
+				desc = msgcat.T("Terraform will destroy all your managed infrastructure, as shown above.\n" +
+					"There is no undo. Only 'yes' will be accepted to confirm.")
 			} else {
 				if op.Workspace != "default" {
-					query = "Do you want to perform these actions in workspace \"" + op.Workspace + "\"?"
+					query = fmt.Sprintf(msgcat.T("Do you want to perform these actions in workspace %q?"), op.Workspace)
 				} else {
-					query = "Do you want to perform these actions?"
+					query = msgcat.T("Do you want to perform these actions?")
 				}
-				desc = "Terraform will perform the actions described above.\n" +
-					"Only 'yes' will be accepted to approve."
+				desc = msgcat.T("Terraform will perform the actions described above.\n" +
+					"Only 'yes' will be accepted to approve.")
 			}
 
 			if !trivialPlan {
@@ -131,9 +188,9 @@ func (b *Local) opApply(
 			}
 			if v != "yes" {
 				if op.Destroy {
-					b.CLI.Info("Destroy cancelled.")
+					b.CLI.Info(msgcat.T("Destroy cancelled."))
 				} else {
-					b.CLI.Info("Apply cancelled.")
+					b.CLI.Info(msgcat.T("Apply cancelled."))
 				}
 				runningOp.Result = backend.OperationFailure
 				return
@@ -193,17 +250,23 @@ func (b *Local) opApply(
 		if op.Destroy {
 			b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
 				"[reset][bold][green]\n"+
-					"Destroy complete! Resources: %d destroyed.",
+					msgcat.T("Destroy complete! Resources: %d destroyed."),
 				countHook.Removed)))
 		} else {
 			b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
 				"[reset][bold][green]\n"+
-					"Apply complete! Resources: %d added, %d changed, %d destroyed.",
+					msgcat.T("Apply complete! Resources: %d added, %d changed, %d destroyed."),
 				countHook.Added,
 				countHook.Changed,
 				countHook.Removed)))
 		}
 
+		if op.ShowTimings {
+			if report := timingHook.Report(); report != "" {
+				b.CLI.Output("\n" + strings.TrimSpace(report))
+			}
+		}
+
 		// only show the state file help message if the state is local.
 		if (countHook.Added > 0 || countHook.Changed > 0) && b.StateOutPath != "" {
 			b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
@@ -5,17 +5,21 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform/backend"
 	"github.com/hashicorp/terraform/command/clistate"
 	"github.com/hashicorp/terraform/configs"
 	"github.com/hashicorp/terraform/configs/configload"
+	"github.com/hashicorp/terraform/lang"
 	"github.com/hashicorp/terraform/plans/planfile"
 	"github.com/hashicorp/terraform/states/statemgr"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/hashicorp/terraform/tfdiags"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 )
 
 // backend.Local implementation.
@@ -24,9 +28,12 @@ func (b *Local) Context(op *backend.Operation) (*terraform.Context, statemgr.Ful
 	// to ask for input/validate.
 	op.Type = backend.OperationTypeInvalid
 
-	if op.LockState {
+	switch {
+	case op.LockState && op.StateLockQueue:
+		op.StateLocker = clistate.NewQueueLocker(context.Background(), op.StateLockTimeout, b.CLI, b.Colorize())
+	case op.LockState:
 		op.StateLocker = clistate.NewLocker(context.Background(), op.StateLockTimeout, b.CLI, b.Colorize())
-	} else {
+	default:
 		op.StateLocker = clistate.NewNoopLocker()
 	}
 
@@ -137,13 +144,19 @@ func (b *Local) contextDirect(op *backend.Operation, opts terraform.ContextOpts)
 	opts.Config = config
 
 	var rawVariables map[string]backend.UnparsedVariableValue
-	if op.AllowUnsetVariables {
+	switch {
+	case op.AllowUnsetVariables:
 		// Rather than prompting for input, we'll just stub out the required
 		// but unset variables with unknown values to represent that they are
 		// placeholders for values the user would need to provide for other
 		// operations.
 		rawVariables = b.stubUnsetRequiredVariables(op.Variables, config.Module.Variables)
-	} else {
+	case op.StrictVariables:
+		// In strict mode we don't prompt interactively, so that a required
+		// variable with no value is reported as an error rather than the
+		// operation waiting on input that will never arrive in automation.
+		rawVariables = op.Variables
+	default:
 		// If interactive input is enabled, we might gather some more variable
 		// values through interactive prompts.
 		// TODO: Need to route the operation context through into here, so that
@@ -151,7 +164,7 @@ func (b *Local) contextDirect(op *backend.Operation, opts terraform.ContextOpts)
 		rawVariables = b.interactiveCollectVariables(context.TODO(), op.Variables, config.Module.Variables, opts.UIInput)
 	}
 
-	variables, varDiags := backend.ParseVariableValues(rawVariables, config.Module.Variables)
+	variables, varDiags := backend.ParseVariableValues(rawVariables, config.Module.Variables, op.StrictVariables)
 	diags = diags.Append(varDiags)
 	if diags.HasErrors() {
 		return nil, nil, diags
@@ -307,21 +320,123 @@ func (b *Local) interactiveCollectVariables(ctx context.Context, existing map[st
 	}
 	for _, name := range needed {
 		vc := vcs[name]
-		rawValue, err := uiInput.Input(ctx, &terraform.InputOpts{
-			Id:          fmt.Sprintf("var.%s", name),
-			Query:       fmt.Sprintf("var.%s", name),
-			Description: vc.Description,
-		})
+		for attempt := 0; ; attempt++ {
+			rawValue, err := uiInput.Input(ctx, &terraform.InputOpts{
+				Id:          fmt.Sprintf("var.%s", name),
+				Query:       fmt.Sprintf("var.%s", name),
+				Description: interactiveVariableDescription(vc),
+			})
+			if err != nil {
+				// Since interactive prompts are best-effort, we'll just
+				// continue here and let subsequent validation report this as
+				// a variable not specified.
+				log.Printf("[WARN] backend/local: Failed to request user input for variable %q: %s", name, err)
+				break
+			}
+
+			// We check the given value immediately so that we can re-prompt
+			// on a mistyped entry, rather than aborting the whole run. After
+			// a few failed attempts we keep the value anyway and let the
+			// normal parsing codepath report the error.
+			valDiags := checkInteractiveVariableValue(name, rawValue, vc)
+			if valDiags.HasErrors() && attempt < maxInteractiveVariableAttempts-1 {
+				b.ShowDiagnostics(valDiags)
+				if b.CLI != nil {
+					b.CLI.Output(fmt.Sprintf("Please re-enter a value for var.%s.\n", name))
+				}
+				continue
+			}
+
+			ret[name] = unparsedInteractiveVariableValue{Name: name, RawValue: rawValue}
+			break
+		}
+	}
+	return ret
+}
+
+// maxInteractiveVariableAttempts is the number of times we'll re-prompt for
+// an input variable whose entered value fails its type constraint or
+// validation rules before giving up and letting the run fail.
+const maxInteractiveVariableAttempts = 3
+
+// interactiveVariableDescription builds the descriptive text shown under an
+// input variable prompt, combining the author's description (if any) with
+// the variable's type constraint.
+func interactiveVariableDescription(vc *configs.Variable) string {
+	var parts []string
+	if vc.Description != "" {
+		parts = append(parts, vc.Description)
+	}
+	if vc.Type != cty.NilType && vc.Type != cty.DynamicPseudoType {
+		parts = append(parts, fmt.Sprintf("Enter a value of type %s.", vc.Type.FriendlyName()))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// checkInteractiveVariableValue parses an interactively-entered value for the
+// given variable and checks it against the variable's type constraint and any
+// validation rules, returning error diagnostics if it is not acceptable.
+//
+// This duplicates checks that would happen later anyway, but doing it here
+// lets us re-prompt while the user is still present instead of aborting.
+func checkInteractiveVariableValue(name, rawValue string, vc *configs.Variable) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	val, valDiags := vc.ParsingMode.Parse(name, rawValue)
+	diags = diags.Append(valDiags)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	if vc.Type != cty.NilType {
+		var err error
+		val, err = convert.Convert(val, vc.Type)
 		if err != nil {
-			// Since interactive prompts are best-effort, we'll just continue
-			// here and let subsequent validation report this as a variable
-			// not specified.
-			log.Printf("[WARN] backend/local: Failed to request user input for variable %q: %s", name, err)
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid value for input variable",
+				fmt.Sprintf("The value entered for variable %q is not valid: %s.", name, err),
+			))
+			return diags
+		}
+	}
+
+	for _, validation := range vc.Validations {
+		if validation.Condition == nil {
 			continue
 		}
-		ret[name] = unparsedInteractiveVariableValue{Name: name, RawValue: rawValue}
+		// The configs package guarantees that the condition refers only to
+		// the variable itself, so a minimal evaluation context suffices.
+		hclCtx := &hcl.EvalContext{
+			Variables: map[string]cty.Value{
+				"var": cty.ObjectVal(map[string]cty.Value{
+					name: val,
+				}),
+			},
+			Functions: (&lang.Scope{}).Functions(),
+		}
+		result, moreDiags := validation.Condition.Value(hclCtx)
+		if moreDiags.HasErrors() {
+			// A failure to evaluate the condition is a bug in the module
+			// rather than a problem with the entered value, so we'll let
+			// the main evaluation codepath report it.
+			continue
+		}
+		result, err := convert.Convert(result, cty.Bool)
+		if err != nil || result.IsNull() || !result.IsKnown() {
+			continue
+		}
+		if result.False() {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid value for input variable",
+				Detail:   fmt.Sprintf("The value entered for variable %q is not valid: %s", name, validation.ErrorMessage),
+				Subject:  validation.DeclRange.Ptr(),
+			})
+		}
 	}
-	return ret
+
+	return diags
 }
 
 // stubUnsetVariables ensures that all required variables defined in the
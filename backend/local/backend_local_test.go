@@ -0,0 +1,93 @@
+package local
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/configs"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func testVariableWithValidation(t *testing.T, name, condSrc, errMsg string) *configs.Variable {
+	t.Helper()
+	expr, diags := hclsyntax.ParseExpression([]byte(condSrc), "test.tf", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		t.Fatalf("invalid condition expression: %s", diags.Error())
+	}
+	return &configs.Variable{
+		Name:        name,
+		Type:        cty.String,
+		ParsingMode: configs.VariableParseLiteral,
+		Validations: []*configs.VariableValidation{
+			{
+				Condition:    expr,
+				ErrorMessage: errMsg,
+			},
+		},
+	}
+}
+
+func TestCheckInteractiveVariableValue(t *testing.T) {
+	vc := testVariableWithValidation(t, "region", `length(var.region) > 2`, "Region names have at least three characters.")
+
+	if diags := checkInteractiveVariableValue("region", "us-east-1", vc); diags.HasErrors() {
+		t.Errorf("valid value was rejected: %s", diags.Err())
+	}
+	diags := checkInteractiveVariableValue("region", "no", vc)
+	if !diags.HasErrors() {
+		t.Fatalf("invalid value was accepted")
+	}
+	if got, want := diags.Err().Error(), "Region names have at least three characters."; !strings.Contains(got, want) {
+		t.Errorf("missing validation message\ngot: %s\nwant substring: %s", got, want)
+	}
+
+	// A value that can't convert to the type constraint is also rejected.
+	numVC := &configs.Variable{
+		Name:        "count",
+		Type:        cty.Number,
+		ParsingMode: configs.VariableParseHCL,
+	}
+	if diags := checkInteractiveVariableValue("count", `"not-a-number"`, numVC); !diags.HasErrors() {
+		t.Errorf("unconvertible value was accepted")
+	}
+}
+
+func TestInteractiveCollectVariables_reprompt(t *testing.T) {
+	b, cleanup := TestLocal(t)
+	defer cleanup()
+	b.OpInput = true
+
+	vc := testVariableWithValidation(t, "region", `length(var.region) > 2`, "Region names have at least three characters.")
+	vcs := map[string]*configs.Variable{"region": vc}
+
+	answers := []string{"no", "us-east-1"}
+	input := &terraform.MockUIInput{
+		InputFn: func(opts *terraform.InputOpts) (string, error) {
+			answer := answers[0]
+			answers = answers[1:]
+			return answer, nil
+		},
+	}
+
+	got := b.interactiveCollectVariables(context.Background(), map[string]backend.UnparsedVariableValue{}, vcs, input)
+	if len(answers) != 0 {
+		t.Fatalf("expected a re-prompt after the first invalid entry")
+	}
+	raw, exists := got["region"]
+	if !exists {
+		t.Fatalf("no value collected for var.region")
+	}
+	val, diags := raw.ParseVariableValue(vc.ParsingMode)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+	if got, want := val.Value, cty.StringVal("us-east-1"); !want.RawEquals(got) {
+		t.Errorf("wrong value %#v; want %#v", got, want)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform/addrs"
 	"github.com/hashicorp/terraform/backend"
 	"github.com/hashicorp/terraform/command/format"
+	"github.com/hashicorp/terraform/configs"
 	"github.com/hashicorp/terraform/plans"
 	"github.com/hashicorp/terraform/plans/planfile"
 	"github.com/hashicorp/terraform/states"
@@ -59,12 +60,16 @@ func (b *Local) opPlan(
 
 	// Setup our count hook that keeps track of resource changes
 	countHook := new(CountHook)
+	timingHook := new(TimingHook)
 	if b.ContextOpts == nil {
 		b.ContextOpts = new(terraform.ContextOpts)
 	}
 	old := b.ContextOpts.Hooks
 	defer func() { b.ContextOpts.Hooks = old }()
 	b.ContextOpts.Hooks = append(b.ContextOpts.Hooks, countHook)
+	if op.ShowTimings {
+		b.ContextOpts.Hooks = append(b.ContextOpts.Hooks, timingHook)
+	}
 
 	// Get our context
 	tfCtx, configSnap, opState, ctxDiags := b.context(op)
@@ -74,6 +79,22 @@ func (b *Local) opPlan(
 		return
 	}
 
+	if op.ProfileDir != "" {
+		stopProfiling, err := startProfiling(op.ProfileDir)
+		if err != nil {
+			diags = diags.Append(err)
+			b.ReportResult(runningOp, diags)
+			return
+		}
+		defer stopProfiling()
+
+		if g, graphDiags := tfCtx.Graph(terraform.GraphTypePlan, nil); !graphDiags.HasErrors() {
+			if err := writeGraphSizeReport(op.ProfileDir, g); err != nil {
+				log.Printf("[ERROR] backend/local: failed to write graph size report: %s", err)
+			}
+		}
+	}
+
 	// Setup the state
 	runningOp.State = tfCtx.State()
 
@@ -125,6 +146,15 @@ func (b *Local) opPlan(
 	// Record state
 	runningOp.PlanEmpty = plan.Changes.Empty()
 
+	// Enforce any plan guardrails from the configuration before we save
+	// or render the plan.
+	limitDiags := checkPlanLimits(tfCtx.Config(), plan, op.ForceThreshold)
+	diags = diags.Append(limitDiags)
+	if limitDiags.HasErrors() {
+		b.ReportResult(runningOp, diags)
+		return
+	}
+
 	// Save the plan to disk
 	if path := op.PlanOutPath; path != "" {
 		if op.PlanOutBackend == nil {
@@ -143,8 +173,15 @@ func (b *Local) opPlan(
 		// only write it if this plan is subsequently applied.
 		plannedStateFile := statemgr.PlannedStateUpdate(opState, baseState)
 
+		// Timings are recorded in the plan file only when the user asked for
+		// them, so that "terraform show -json" can include them later.
+		var planTimings []planfile.ResourceTiming
+		if op.ShowTimings {
+			planTimings = timingHook.PlanFileTimings()
+		}
+
 		log.Printf("[INFO] backend/local: writing plan output to: %s", path)
-		err := planfile.Create(path, configSnap, plannedStateFile, plan)
+		err := planfile.Create(path, configSnap, plannedStateFile, plan, planTimings)
 		if err != nil {
 			diags = diags.Append(tfdiags.Sourceless(
 				tfdiags.Error,
@@ -162,6 +199,15 @@ func (b *Local) opPlan(
 
 		if plan.Changes.Empty() {
 			b.CLI.Output("\n" + b.Colorize().Color(strings.TrimSpace(planNoChanges)))
+			if op.ShowTimings {
+				if report := timingHook.Report(); report != "" {
+					b.CLI.Output("\n" + strings.TrimSpace(report))
+				}
+			}
+			if op.TraceExpr != "" {
+				b.CLI.Output("")
+				diags = diags.Append(b.renderExprTrace(tfCtx, plan, op.ConfigLoader.Sources(), op.TraceExpr, op.TraceExprShowSensitive))
+			}
 			// Even if there are no changes, there still could be some warnings
 			b.ShowDiagnostics(diags)
 			return
@@ -169,6 +215,22 @@ func (b *Local) opPlan(
 
 		b.renderPlan(plan, baseState, schemas)
 
+		if op.ExplainUnknowns {
+			b.CLI.Output("")
+			diags = diags.Append(b.renderUnknownExplanations(tfCtx, plan))
+		}
+
+		if op.TraceExpr != "" {
+			b.CLI.Output("")
+			diags = diags.Append(b.renderExprTrace(tfCtx, plan, op.ConfigLoader.Sources(), op.TraceExpr, op.TraceExprShowSensitive))
+		}
+
+		if op.ShowTimings {
+			if report := timingHook.Report(); report != "" {
+				b.CLI.Output("\n" + strings.TrimSpace(report))
+			}
+		}
+
 		// If we've accumulated any warnings along the way then we'll show them
 		// here just before we show the summary and next steps. If we encountered
 		// errors then we would've returned early at some other point above.
@@ -198,6 +260,64 @@ func (b *Local) renderPlan(plan *plans.Plan, state *states.State, schemas *terra
 	RenderPlan(plan, state, schemas, b.CLI, b.Colorize())
 }
 
+// checkPlanLimits verifies a completed plan against the max_destroy and
+// max_changes guardrails from the root module configuration, if any are set.
+// Exceeding a limit produces an error diagnostic, or just a warning when
+// force is set (the -force-threshold CLI option).
+func checkPlanLimits(config *configs.Config, plan *plans.Plan, force bool) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	if config == nil || config.Module == nil || plan == nil {
+		return diags
+	}
+	mod := config.Module
+	if mod.MaxDestroy == nil && mod.MaxChanges == nil {
+		return diags
+	}
+
+	var destroys, changes int
+	for _, rc := range plan.Changes.Resources {
+		if rc.Addr.Resource.Resource.Mode != addrs.ManagedResourceMode {
+			// Planned actions on data resources don't change any real
+			// infrastructure, so they don't count against the limits.
+			continue
+		}
+		if rc.Action == plans.NoOp {
+			continue
+		}
+		changes++
+		if rc.Action == plans.Delete || rc.Action.IsReplace() {
+			destroys++
+		}
+	}
+
+	if limit := mod.MaxDestroy; limit != nil && destroys > limit.Value {
+		diags = diags.Append(planLimitDiag(force, "max_destroy", "destroy", destroys, limit))
+	}
+	if limit := mod.MaxChanges; limit != nil && changes > limit.Value {
+		diags = diags.Append(planLimitDiag(force, "max_changes", "change", changes, limit))
+	}
+	return diags
+}
+
+func planLimitDiag(force bool, name, verb string, count int, limit *configs.PlanLimit) tfdiags.Diagnostic {
+	detail := fmt.Sprintf(
+		"This plan would %s %d resource instances, but the configuration sets %s = %d (at %s).",
+		verb, count, name, limit.Value, limit.DeclRange,
+	)
+	if force {
+		return tfdiags.Sourceless(
+			tfdiags.Warning,
+			fmt.Sprintf("Plan exceeds %s limit", name),
+			detail+" Proceeding anyway because the -force-threshold option was set.",
+		)
+	}
+	return tfdiags.Sourceless(
+		tfdiags.Error,
+		fmt.Sprintf("Plan exceeds %s limit", name),
+		detail+" If these changes are intended, re-run with the -force-threshold option to override the limit.",
+	)
+}
+
 // RenderPlan renders the given plan to the given UI.
 //
 // This is exported only so that the "terraform show" command can re-use it.
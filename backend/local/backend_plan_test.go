@@ -130,6 +130,145 @@ func TestLocal_planNoConfig(t *testing.T) {
 	}
 }
 
+func TestLocal_planExceedsLimits(t *testing.T) {
+	b, cleanup := TestLocal(t)
+	defer cleanup()
+	TestLocalProvider(t, b, "test", planFixtureSchema())
+
+	b.CLI = cli.NewMockUi()
+
+	// The fixture sets max_changes = 1 but plans two creates, so the
+	// operation should fail.
+	op, configCleanup := testOperationPlan(t, "./testdata/plan-limits")
+	defer configCleanup()
+	op.PlanRefresh = true
+
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+
+	if run.Result == backend.OperationSuccess {
+		t.Fatal("plan operation succeeded; want failure")
+	}
+	output := b.CLI.(*cli.MockUi).ErrorWriter.String()
+	if !strings.Contains(output, "max_changes") {
+		t.Fatalf("missing max_changes error in output:\n%s", output)
+	}
+
+	// ForceThreshold downgrades the limit to a warning, so the same plan
+	// should now succeed.
+	b.CLI = cli.NewMockUi()
+	op, configCleanup = testOperationPlan(t, "./testdata/plan-limits")
+	defer configCleanup()
+	op.PlanRefresh = true
+	op.ForceThreshold = true
+
+	run, err = b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+
+	if run.Result != backend.OperationSuccess {
+		t.Fatalf("plan operation failed with -force-threshold:\n%s", b.CLI.(*cli.MockUi).ErrorWriter.String())
+	}
+}
+
+func TestLocal_planTraceExpr(t *testing.T) {
+	b, cleanup := TestLocal(t)
+	defer cleanup()
+	TestLocalProvider(t, b, "test", planFixtureSchema())
+
+	b.CLI = cli.NewMockUi()
+
+	op, configCleanup := testOperationPlan(t, "./testdata/plan-trace")
+	defer configCleanup()
+	op.PlanRefresh = true
+	op.TraceExpr = "test_instance.foo.ami"
+
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	if run.Result != backend.OperationSuccess {
+		t.Fatalf("plan operation failed:\n%s", b.CLI.(*cli.MockUi).ErrorWriter.String())
+	}
+
+	output := b.CLI.(*cli.MockUi).OutputWriter.String()
+	for _, want := range []string{
+		"Expression trace for test_instance.foo.ami",
+		"upper(var.image)",
+		"var.image = \"ami-1234\"",
+		"Functions:  upper",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("missing %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestTraceValueStr_sensitive(t *testing.T) {
+	val := cty.StringVal("hunter2").Mark("sensitive")
+
+	if got, want := traceValueStr(val, false), "(sensitive value)"; got != want {
+		t.Errorf("wrong redacted rendering %q; want %q", got, want)
+	}
+
+	got := traceValueStr(val, true)
+	if !strings.Contains(got, "hunter2") {
+		t.Errorf("value missing from opt-in rendering %q", got)
+	}
+	if !strings.Contains(got, "(marked as sensitive)") {
+		t.Errorf("sensitivity note missing from opt-in rendering %q", got)
+	}
+}
+
+func TestLocal_planExplainUnknowns(t *testing.T) {
+	b, cleanup := TestLocal(t)
+	defer cleanup()
+	schema := &terraform.ProviderSchema{
+		ResourceTypes: map[string]*configschema.Block{
+			"test_instance": {
+				Attributes: map[string]*configschema.Attribute{
+					"ami": {Type: cty.String, Optional: true},
+					"id":  {Type: cty.String, Computed: true},
+				},
+			},
+		},
+	}
+	TestLocalProvider(t, b, "test", schema)
+
+	b.CLI = cli.NewMockUi()
+
+	op, configCleanup := testOperationPlan(t, "./testdata/plan-unknowns")
+	defer configCleanup()
+	op.PlanRefresh = true
+	op.ExplainUnknowns = true
+
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	if run.Result != backend.OperationSuccess {
+		t.Fatalf("plan operation failed:\n%s", b.CLI.(*cli.MockUi).ErrorWriter.String())
+	}
+
+	output := b.CLI.(*cli.MockUi).OutputWriter.String()
+	for _, want := range []string{
+		"Unknown value provenance:",
+		"test_instance.bar.ami: unknown because of test_instance.foo.id",
+		"test_instance.bar.id: will be computed by the provider",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("missing %q in output:\n%s", want, output)
+		}
+	}
+}
+
 func TestLocal_planTainted(t *testing.T) {
 	b, cleanup := TestLocal(t)
 	defer cleanup()
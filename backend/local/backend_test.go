@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/states/statefile"
 	"github.com/hashicorp/terraform/states/statemgr"
 )
@@ -23,8 +24,8 @@ func TestLocal_impl(t *testing.T) {
 func TestLocal_backend(t *testing.T) {
 	defer testTmpDir(t)()
 	b := New()
-	backend.TestBackendStates(t, b)
-	backend.TestBackendStateLocks(t, b, b)
+	backendtest.TestBackendStates(t, b)
+	backendtest.TestBackendStateLocks(t, b, b)
 }
 
 func checkState(t *testing.T, path, expected string) {
@@ -48,6 +49,28 @@ func checkState(t *testing.T, path, expected string) {
 	}
 }
 
+func TestLocal_backupRetentionConfig(t *testing.T) {
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"backup_dir": "state-backups",
+	})).(*Local)
+
+	if b.StateBackupDir != "state-backups" {
+		t.Fatalf("wrong backup dir %q", b.StateBackupDir)
+	}
+	if b.StateBackupRetention != DefaultBackupRetention {
+		t.Fatalf("wrong default retention %d; want %d", b.StateBackupRetention, DefaultBackupRetention)
+	}
+
+	b = backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"backup_dir":       "state-backups",
+		"backup_retention": 3,
+	})).(*Local)
+
+	if b.StateBackupRetention != 3 {
+		t.Fatalf("wrong retention %d; want 3", b.StateBackupRetention)
+	}
+}
+
 func TestLocal_StatePaths(t *testing.T) {
 	b := New()
 
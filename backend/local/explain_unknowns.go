@@ -0,0 +1,133 @@
+package local
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/configs"
+	"github.com/hashicorp/terraform/lang"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform/tfdiags"
+)
+
+// renderUnknownExplanations prints, for each planned attribute value that is
+// not yet known, the upstream reference that caused it to be unknown. This
+// backs the -explain-unknowns option of "terraform plan".
+func (b *Local) renderUnknownExplanations(tfCtx *terraform.Context, plan *plans.Plan) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	// Reference values are resolved against one evaluation scope per module
+	// instance, created lazily since most plans won't need any at all.
+	scopes := map[string]*lang.Scope{}
+	scopeFor := func(module addrs.ModuleInstance) *lang.Scope {
+		key := module.String()
+		if scope, exists := scopes[key]; exists {
+			return scope
+		}
+		scope, _ := tfCtx.Eval(module)
+		scopes[key] = scope
+		return scope
+	}
+
+	var lines []string
+	for _, rc := range plan.Changes.Resources {
+		if rc.Action == plans.NoOp || rc.Addr.Resource.Resource.Mode != addrs.ManagedResourceMode {
+			continue
+		}
+		schema, _ := tfCtx.Schemas().ResourceTypeConfig(rc.ProviderAddr.Provider, rc.Addr.Resource.Resource.Mode, rc.Addr.Resource.Resource.Type)
+		if schema == nil {
+			continue
+		}
+		change, err := rc.Decode(schema.ImpliedType())
+		if err != nil || change.After == cty.NilVal || change.After.IsWhollyKnown() {
+			continue
+		}
+
+		var rcfg *configs.Resource
+		if cfg := tfCtx.Config().DescendentForInstance(rc.Addr.Module); cfg != nil {
+			rcfg = cfg.Module.ResourceByAddr(rc.Addr.Resource.Resource)
+		}
+
+		attrNames := make([]string, 0, len(schema.Attributes))
+		for name := range schema.Attributes {
+			attrNames = append(attrNames, name)
+		}
+		sort.Strings(attrNames)
+
+		for _, name := range attrNames {
+			if change.After.GetAttr(name).IsWhollyKnown() {
+				continue
+			}
+			reason := explainUnknownAttr(scopeFor(rc.Addr.Module), rcfg, name)
+			lines = append(lines, fmt.Sprintf("  - %s.%s: %s", rc.Addr, name, reason))
+		}
+	}
+
+	if len(lines) == 0 {
+		b.CLI.Output("All planned values are known; nothing to explain.")
+		return diags
+	}
+	b.CLI.Output("Unknown value provenance:\n" + strings.Join(lines, "\n"))
+	return diags
+}
+
+// explainUnknownAttr describes why the named attribute of the given resource
+// configuration is not yet known, by finding the first reference in its
+// configuration expression whose own value is unknown.
+func explainUnknownAttr(scope *lang.Scope, rcfg *configs.Resource, name string) string {
+	if rcfg == nil {
+		return "will be computed by the provider"
+	}
+	content, _, _ := rcfg.Config.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: name}},
+	})
+	attr := content.Attributes[name]
+	if attr == nil {
+		return "will be computed by the provider"
+	}
+
+	if scope != nil {
+		refs, _ := lang.ReferencesInExpr(attr.Expr)
+		for _, ref := range refs {
+			val := evalReferenceValue(scope, ref)
+			if val != cty.NilVal && len(ref.Remaining) > 0 {
+				if after, travDiags := ref.Remaining.TraverseRel(val); !travDiags.HasErrors() {
+					val = after
+				}
+			}
+			if val == cty.NilVal || !val.IsWhollyKnown() {
+				return fmt.Sprintf("unknown because of %s (expression at %s)", referenceDisplay(ref), attr.Expr.Range())
+			}
+		}
+	}
+	return fmt.Sprintf("expression at %s is not yet known", attr.Expr.Range())
+}
+
+// referenceDisplay renders a reference as it would appear in configuration,
+// including any attribute or index steps after the referenced object.
+func referenceDisplay(ref *addrs.Reference) string {
+	var buf strings.Builder
+	buf.WriteString(ref.Subject.String())
+	for _, step := range ref.Remaining {
+		switch ts := step.(type) {
+		case hcl.TraverseAttr:
+			fmt.Fprintf(&buf, ".%s", ts.Name)
+		case hcl.TraverseIndex:
+			switch {
+			case ts.Key.Type() == cty.String:
+				fmt.Fprintf(&buf, "[%q]", ts.Key.AsString())
+			case ts.Key.Type() == cty.Number:
+				fmt.Fprintf(&buf, "[%s]", ts.Key.AsBigFloat().String())
+			default:
+				buf.WriteString("[...]")
+			}
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,227 @@
+package local
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/lang"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform/tfdiags"
+)
+
+// renderExprTrace prints a report describing how the value of the resource
+// attribute named by traceExpr was derived: the source expression from the
+// configuration, the values of any references it makes, the functions it
+// applies, and the final planned value. This backs the -trace-expr option
+// of "terraform plan".
+//
+// Sensitive values are redacted from the report unless showSensitive is
+// set, since trace output commonly ends up in CI logs.
+func (b *Local) renderExprTrace(tfCtx *terraform.Context, plan *plans.Plan, sources map[string][]byte, traceExpr string, showSensitive bool) tfdiags.Diagnostics {
+	addr, attrTraversal, diags := parseExprTraceAddr(traceExpr)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	if len(attrTraversal) == 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid -trace-expr address",
+			fmt.Sprintf("The -trace-expr address %q names a whole resource. Include the name of an attribute to trace, such as %q.", traceExpr, traceExpr+".id"),
+		))
+		return diags
+	}
+	firstStep, ok := attrTraversal[0].(hcl.TraverseAttr)
+	if !ok {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid -trace-expr address",
+			fmt.Sprintf("The part of the -trace-expr address after the resource address %s must begin with an attribute name.", addr),
+		))
+		return diags
+	}
+	attrName := firstStep.Name
+
+	cfg := tfCtx.Config().DescendentForInstance(addr.Module)
+	if cfg == nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Module not found for -trace-expr",
+			fmt.Sprintf("The configuration has no module at %s, given in the -trace-expr address %q.", addr.Module, traceExpr),
+		))
+		return diags
+	}
+	rc := cfg.Module.ResourceByAddr(addr.Resource.Resource)
+	if rc == nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Resource not found for -trace-expr",
+			fmt.Sprintf("The configuration has no resource %s, given in the -trace-expr address %q.", addr.ContainingResource(), traceExpr),
+		))
+		return diags
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "Expression trace for %s.%s:\n", addr, attrName)
+
+	// The attribute may not be set in configuration at all, in which case
+	// its value is either a default or decided by the provider.
+	content, _, _ := rc.Config.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: attrName}},
+	})
+	attr := content.Attributes[attrName]
+
+	scope, evalDiags := tfCtx.Eval(addr.Module)
+	if evalDiags.HasErrors() || scope == nil {
+		diags = diags.Append(evalDiags)
+		return diags
+	}
+
+	if attr != nil {
+		rng := attr.Expr.Range()
+		fmt.Fprintf(&buf, "  Source:     %s\n", rng)
+		if src, exists := sources[rng.Filename]; exists {
+			fmt.Fprintf(&buf, "  Expression: %s\n", strings.TrimSpace(string(rng.SliceBytes(src))))
+		}
+
+		if funcs := functionCallsInExpr(attr.Expr); len(funcs) != 0 {
+			fmt.Fprintf(&buf, "  Functions:  %s\n", strings.Join(funcs, ", "))
+		}
+
+		refs, _ := lang.ReferencesInExpr(attr.Expr)
+		if len(refs) != 0 {
+			buf.WriteString("  References:\n")
+			for _, ref := range refs {
+				fmt.Fprintf(&buf, "    %s = %s\n", ref.Subject, traceValueStr(evalReferenceValue(scope, ref), showSensitive))
+			}
+		}
+
+		configVal, _ := scope.EvalExpr(attr.Expr, cty.DynamicPseudoType)
+		fmt.Fprintf(&buf, "  Config value:  %s\n", traceValueStr(configVal, showSensitive))
+	} else {
+		buf.WriteString("  Not set in configuration; the value is a default or is decided by the provider.\n")
+	}
+
+	// If the plan includes a change for this resource instance then we can
+	// also report the value the attribute will have after apply.
+	if rcs := plan.Changes.ResourceInstance(addr); rcs != nil {
+		schema, _ := tfCtx.Schemas().ResourceTypeConfig(rcs.ProviderAddr.Provider, addr.Resource.Resource.Mode, addr.Resource.Resource.Type)
+		if schema != nil {
+			if change, err := rcs.Decode(schema.ImpliedType()); err == nil && change.After != cty.NilVal {
+				if plannedVal, travDiags := attrTraversal.TraverseRel(change.After); !travDiags.HasErrors() {
+					fmt.Fprintf(&buf, "  Planned value: %s\n", traceValueStr(plannedVal, showSensitive))
+				}
+			}
+		}
+	}
+
+	b.CLI.Output(strings.TrimRight(buf.String(), "\n"))
+	return diags
+}
+
+// parseExprTraceAddr splits a -trace-expr argument into the resource instance
+// address it begins with and the remaining attribute traversal.
+func parseExprTraceAddr(raw string) (addrs.AbsResourceInstance, hcl.Traversal, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	traversal, parseDiags := hclsyntax.ParseTraversalAbs([]byte(raw), "", hcl.Pos{Line: 1, Column: 1})
+	if parseDiags.HasErrors() {
+		diags = diags.Append(parseDiags)
+		return addrs.AbsResourceInstance{}, nil, diags
+	}
+
+	// The resource address is the longest prefix of the traversal that
+	// parses as a resource target; the rest names an attribute within it.
+	for i := len(traversal); i > 0; i-- {
+		target, targetDiags := addrs.ParseTarget(traversal[:i])
+		if targetDiags.HasErrors() {
+			continue
+		}
+		switch subj := target.Subject.(type) {
+		case addrs.AbsResourceInstance:
+			return subj, traversal[i:], diags
+		case addrs.AbsResource:
+			return subj.Instance(addrs.NoKey), traversal[i:], diags
+		}
+	}
+
+	diags = diags.Append(tfdiags.Sourceless(
+		tfdiags.Error,
+		"Invalid -trace-expr address",
+		fmt.Sprintf("The -trace-expr address %q must begin with a resource instance address, followed by the name of an attribute to trace.", raw),
+	))
+	return addrs.AbsResourceInstance{}, nil, diags
+}
+
+// evalReferenceValue evaluates a single reference in the given scope by
+// synthesizing an expression from its address, returning cty.NilVal if the
+// reference cannot be evaluated.
+func evalReferenceValue(scope *lang.Scope, ref *addrs.Reference) cty.Value {
+	expr, parseDiags := hclsyntax.ParseExpression([]byte(ref.Subject.String()), "", hcl.Pos{Line: 1, Column: 1})
+	if parseDiags.HasErrors() {
+		return cty.NilVal
+	}
+	val, diags := scope.EvalExpr(expr, cty.DynamicPseudoType)
+	if diags.HasErrors() {
+		return cty.NilVal
+	}
+	return val
+}
+
+// functionCallsInExpr returns the names of any functions called within the
+// given expression, in source order. Only expressions in HCL native syntax
+// can be analyzed this way; other expressions yield no results.
+func functionCallsInExpr(expr hcl.Expression) []string {
+	native, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		return nil
+	}
+	var names []string
+	seen := make(map[string]bool)
+	hclsyntax.VisitAll(native, func(node hclsyntax.Node) hcl.Diagnostics {
+		if call, ok := node.(*hclsyntax.FunctionCallExpr); ok {
+			if !seen[call.Name] {
+				seen[call.Name] = true
+				names = append(names, call.Name)
+			}
+		}
+		return nil
+	})
+	return names
+}
+
+// traceValueStr renders a value for inclusion in an expression trace report,
+// noting unknown values rather than their contents. Sensitive values are
+// redacted, just as in the plan rendering, unless showSensitive is set.
+func traceValueStr(val cty.Value, showSensitive bool) string {
+	if val == cty.NilVal {
+		return "(not evaluated)"
+	}
+	var marksNote string
+	if val.ContainsMarked() {
+		if !showSensitive {
+			return "(sensitive value)"
+		}
+		val, _ = val.UnmarkDeep()
+		marksNote = " (marked as sensitive)"
+	}
+	switch {
+	case !val.IsWhollyKnown():
+		return "(known after apply)" + marksNote
+	case val.IsNull():
+		return "null" + marksNote
+	default:
+		src, err := ctyjson.Marshal(val, val.Type())
+		if err != nil {
+			return fmt.Sprintf("%#v%s", val, marksNote)
+		}
+		return string(src) + marksNote
+	}
+}
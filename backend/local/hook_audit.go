@@ -0,0 +1,105 @@
+package local
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/internal/audit"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// AuditHook is a hook that emits one audit record per resource apply
+// operation to a sink, recording the action, checksums of the old and new
+// objects, the duration, and the outcome.
+type AuditHook struct {
+	Sink audit.Sink
+
+	pending map[string]auditPending
+
+	sync.Mutex
+	terraform.NilHook
+}
+
+var _ terraform.Hook = (*AuditHook)(nil)
+
+// auditPending holds what PreApply learns about an operation until
+// PostApply completes the record.
+type auditPending struct {
+	started     time.Time
+	action      plans.Action
+	oldChecksum string
+}
+
+func (h *AuditHook) PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (terraform.HookAction, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.pending == nil {
+		h.pending = make(map[string]auditPending)
+	}
+	h.pending[addr.String()] = auditPending{
+		started:     time.Now(),
+		action:      action,
+		oldChecksum: auditValueChecksum(priorState),
+	}
+	return terraform.HookActionContinue, nil
+}
+
+func (h *AuditHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generation, newState cty.Value, applyErr error) (terraform.HookAction, error) {
+	h.Lock()
+	pending, ok := h.pending[addr.String()]
+	delete(h.pending, addr.String())
+	h.Unlock()
+	if !ok {
+		return terraform.HookActionContinue, nil
+	}
+
+	rec := &audit.Record{
+		Time:    time.Now(),
+		Address: addr.String(),
+		Action:  pending.action.String(),
+		// The implied provider type is the best identification we have at
+		// this layer; hooks are not told which provider configuration
+		// performed each operation.
+		Provider:        addr.Resource.Resource.ImpliedProvider(),
+		OldChecksum:     pending.oldChecksum,
+		NewChecksum:     auditValueChecksum(newState),
+		DurationSeconds: time.Since(pending.started).Seconds(),
+		Outcome:         "success",
+	}
+	if applyErr != nil {
+		rec.Outcome = "error"
+		rec.Error = applyErr.Error()
+	}
+
+	if err := h.Sink.WriteRecord(rec); err != nil {
+		// A failure to record must not interrupt the apply itself; the
+		// user is told about a broken audit log when the sink is closed.
+		log.Printf("[ERROR] AuditHook: failed to write audit record for %s: %s", addr, err)
+	}
+	return terraform.HookActionContinue, nil
+}
+
+// auditValueChecksum produces the checksum of a resource instance object
+// for an audit record, by hashing its JSON serialization. Absent objects
+// produce an empty checksum.
+func auditValueChecksum(v cty.Value) string {
+	if v == cty.NilVal || v.IsNull() || !v.IsWhollyKnown() {
+		return ""
+	}
+	src, err := ctyjson.Marshal(v, v.Type())
+	if err != nil {
+		// Should never happen for a valid resource object; treat it the
+		// same as an absent object rather than failing the operation.
+		log.Printf("[ERROR] AuditHook: failed to serialize object for checksum: %s", err)
+		return ""
+	}
+	return audit.Checksum(src)
+}
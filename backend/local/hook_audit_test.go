@@ -0,0 +1,111 @@
+package local
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/internal/audit"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// testAuditSink collects records in memory.
+type testAuditSink struct {
+	records []*audit.Record
+	closed  bool
+}
+
+func (s *testAuditSink) WriteRecord(rec *audit.Record) error {
+	s.records = append(s.records, rec)
+	return nil
+}
+
+func (s *testAuditSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestAuditHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(AuditHook)
+}
+
+func TestAuditHook(t *testing.T) {
+	sink := &testAuditSink{}
+	h := &AuditHook{Sink: sink}
+
+	addr := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "aws_instance",
+		Name: "foo",
+	}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+
+	priorState := cty.ObjectVal(map[string]cty.Value{
+		"id": cty.StringVal("before"),
+	})
+	newState := cty.ObjectVal(map[string]cty.Value{
+		"id": cty.StringVal("after"),
+	})
+
+	h.PreApply(addr, states.CurrentGen, plans.Update, priorState, newState)
+	h.PostApply(addr, states.CurrentGen, newState, nil)
+
+	if len(sink.records) != 1 {
+		t.Fatalf("wrong number of records %d; want 1", len(sink.records))
+	}
+	rec := sink.records[0]
+	if got, want := rec.Address, "aws_instance.foo"; got != want {
+		t.Errorf("wrong address %q; want %q", got, want)
+	}
+	if got, want := rec.Action, "Update"; got != want {
+		t.Errorf("wrong action %q; want %q", got, want)
+	}
+	if got, want := rec.Provider, "aws"; got != want {
+		t.Errorf("wrong provider %q; want %q", got, want)
+	}
+	if got, want := rec.Outcome, "success"; got != want {
+		t.Errorf("wrong outcome %q; want %q", got, want)
+	}
+	if rec.OldChecksum == "" || rec.NewChecksum == "" {
+		t.Errorf("missing checksums in record: %#v", rec)
+	}
+	if rec.OldChecksum == rec.NewChecksum {
+		t.Errorf("old and new checksums are equal for different objects: %q", rec.OldChecksum)
+	}
+	if rec.DurationSeconds < 0 {
+		t.Errorf("negative duration %f", rec.DurationSeconds)
+	}
+}
+
+func TestAuditHookError(t *testing.T) {
+	sink := &testAuditSink{}
+	h := &AuditHook{Sink: sink}
+
+	addr := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "aws_instance",
+		Name: "foo",
+	}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+
+	h.PreApply(addr, states.CurrentGen, plans.Delete, cty.ObjectVal(map[string]cty.Value{
+		"id": cty.StringVal("before"),
+	}), cty.NilVal)
+	h.PostApply(addr, states.CurrentGen, cty.NilVal, errors.New("provider exploded"))
+
+	if len(sink.records) != 1 {
+		t.Fatalf("wrong number of records %d; want 1", len(sink.records))
+	}
+	rec := sink.records[0]
+	if got, want := rec.Outcome, "error"; got != want {
+		t.Errorf("wrong outcome %q; want %q", got, want)
+	}
+	if got, want := rec.Error, "provider exploded"; got != want {
+		t.Errorf("wrong error %q; want %q", got, want)
+	}
+	if rec.NewChecksum != "" {
+		t.Errorf("non-empty new checksum %q for absent new object", rec.NewChecksum)
+	}
+}
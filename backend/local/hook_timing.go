@@ -0,0 +1,192 @@
+package local
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/hashicorp/terraform/plans/planfile"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// TimingHook is a hook that records the wall-clock duration of each
+// per-resource provider operation (refresh, plan, and apply) during a walk,
+// so that the slowest resources and providers can be reported afterwards.
+type TimingHook struct {
+	timings []ResourceTiming
+	pending map[string]time.Time
+
+	sync.Mutex
+	terraform.NilHook
+}
+
+var _ terraform.Hook = (*TimingHook)(nil)
+
+// ResourceTiming describes the duration of one provider operation against
+// one resource instance.
+type ResourceTiming struct {
+	Addr      addrs.AbsResourceInstance
+	Operation string // "refresh", "plan" or "apply"
+	Duration  time.Duration
+}
+
+func (h *TimingHook) start(addr addrs.AbsResourceInstance, operation string) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.pending == nil {
+		h.pending = make(map[string]time.Time)
+	}
+	h.pending[operation+" "+addr.String()] = time.Now()
+}
+
+func (h *TimingHook) stop(addr addrs.AbsResourceInstance, operation string) {
+	h.Lock()
+	defer h.Unlock()
+
+	key := operation + " " + addr.String()
+	started, ok := h.pending[key]
+	if !ok {
+		return
+	}
+	delete(h.pending, key)
+
+	h.timings = append(h.timings, ResourceTiming{
+		Addr:      addr,
+		Operation: operation,
+		Duration:  time.Since(started),
+	})
+}
+
+func (h *TimingHook) PreRefresh(addr addrs.AbsResourceInstance, gen states.Generation, priorState cty.Value) (terraform.HookAction, error) {
+	h.start(addr, "refresh")
+	return terraform.HookActionContinue, nil
+}
+
+func (h *TimingHook) PostRefresh(addr addrs.AbsResourceInstance, gen states.Generation, priorState cty.Value, newState cty.Value) (terraform.HookAction, error) {
+	h.stop(addr, "refresh")
+	return terraform.HookActionContinue, nil
+}
+
+func (h *TimingHook) PreDiff(addr addrs.AbsResourceInstance, gen states.Generation, priorState, proposedNewState cty.Value) (terraform.HookAction, error) {
+	h.start(addr, "plan")
+	return terraform.HookActionContinue, nil
+}
+
+func (h *TimingHook) PostDiff(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (terraform.HookAction, error) {
+	h.stop(addr, "plan")
+	return terraform.HookActionContinue, nil
+}
+
+func (h *TimingHook) PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (terraform.HookAction, error) {
+	h.start(addr, "apply")
+	return terraform.HookActionContinue, nil
+}
+
+func (h *TimingHook) PostApply(addr addrs.AbsResourceInstance, gen states.Generation, newState cty.Value, err error) (terraform.HookAction, error) {
+	h.stop(addr, "apply")
+	return terraform.HookActionContinue, nil
+}
+
+// Timings returns the recorded per-resource operation durations, slowest
+// first. Timings for equal durations sort by address so the report is
+// deterministic.
+func (h *TimingHook) Timings() []ResourceTiming {
+	h.Lock()
+	defer h.Unlock()
+
+	ret := make([]ResourceTiming, len(h.timings))
+	copy(ret, h.timings)
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Duration == ret[j].Duration {
+			return ret[i].Addr.String() < ret[j].Addr.String()
+		}
+		return ret[i].Duration > ret[j].Duration
+	})
+	return ret
+}
+
+// PlanFileTimings returns the recorded timings in the wire format used to
+// embed them in a saved plan file, slowest first, or nil if nothing was
+// recorded.
+func (h *TimingHook) PlanFileTimings() []planfile.ResourceTiming {
+	timings := h.Timings()
+	if len(timings) == 0 {
+		return nil
+	}
+	ret := make([]planfile.ResourceTiming, len(timings))
+	for i, t := range timings {
+		ret[i] = planfile.ResourceTiming{
+			Address:         t.Addr.String(),
+			Operation:       t.Operation,
+			DurationSeconds: t.Duration.Seconds(),
+		}
+	}
+	return ret
+}
+
+// timingReportLimit is the maximum number of individual resources shown in
+// the timing report; aggregate provider totals always include everything.
+const timingReportLimit = 10
+
+// Report renders a human-readable summary of the slowest resources and the
+// total time spent in each provider, suitable for printing after a plan or
+// apply when the user requested timing information.
+func (h *TimingHook) Report() string {
+	timings := h.Timings()
+	if len(timings) == 0 {
+		return ""
+	}
+
+	var buf strings.Builder
+	buf.WriteString("Operation timings (slowest resources):\n")
+
+	shown := timings
+	if len(shown) > timingReportLimit {
+		shown = shown[:timingReportLimit]
+	}
+	for _, t := range shown {
+		buf.WriteString(fmt.Sprintf(
+			"  %s: %s (%s)\n",
+			t.Addr, t.Duration.Round(time.Millisecond), t.Operation,
+		))
+	}
+	if remain := len(timings) - len(shown); remain > 0 {
+		buf.WriteString(fmt.Sprintf("  ... and %d more\n", remain))
+	}
+
+	// Aggregate by the implied provider type of each resource, which is the
+	// best identification we have at this layer; hooks are not told which
+	// provider configuration performed each operation.
+	byProvider := make(map[string]time.Duration)
+	for _, t := range timings {
+		byProvider[t.Addr.Resource.Resource.ImpliedProvider()] += t.Duration
+	}
+	providers := make([]string, 0, len(byProvider))
+	for name := range byProvider {
+		providers = append(providers, name)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		if byProvider[providers[i]] == byProvider[providers[j]] {
+			return providers[i] < providers[j]
+		}
+		return byProvider[providers[i]] > byProvider[providers[j]]
+	})
+
+	buf.WriteString("\nTotal time in provider operations:\n")
+	for _, name := range providers {
+		buf.WriteString(fmt.Sprintf(
+			"  %s: %s\n",
+			name, byProvider[name].Round(time.Millisecond),
+		))
+	}
+
+	return buf.String()
+}
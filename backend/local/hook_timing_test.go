@@ -0,0 +1,81 @@
+package local
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestTimingHook_impl(t *testing.T) {
+	var _ terraform.Hook = new(TimingHook)
+}
+
+func TestTimingHook(t *testing.T) {
+	h := new(TimingHook)
+
+	addrSlow := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "aws_instance",
+		Name: "slow",
+	}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+	addrFast := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "null_resource",
+		Name: "fast",
+	}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+
+	h.PreApply(addrSlow, states.CurrentGen, plans.Create, cty.NilVal, cty.NilVal)
+	time.Sleep(10 * time.Millisecond)
+	h.PreApply(addrFast, states.CurrentGen, plans.Create, cty.NilVal, cty.NilVal)
+	h.PostApply(addrFast, states.CurrentGen, cty.NilVal, nil)
+	h.PostApply(addrSlow, states.CurrentGen, cty.NilVal, nil)
+
+	timings := h.Timings()
+	if got, want := len(timings), 2; got != want {
+		t.Fatalf("wrong number of timings %d; want %d", got, want)
+	}
+	if got, want := timings[0].Addr.String(), "aws_instance.slow"; got != want {
+		t.Errorf("wrong slowest resource %q; want %q", got, want)
+	}
+	if got, want := timings[0].Operation, "apply"; got != want {
+		t.Errorf("wrong operation %q; want %q", got, want)
+	}
+	if timings[0].Duration < timings[1].Duration {
+		t.Errorf("timings not sorted slowest-first: %#v", timings)
+	}
+
+	report := h.Report()
+	if !strings.Contains(report, "aws_instance.slow") {
+		t.Errorf("report does not mention slowest resource:\n%s", report)
+	}
+	if !strings.Contains(report, "aws:") {
+		t.Errorf("report does not aggregate by provider:\n%s", report)
+	}
+}
+
+func TestTimingHook_unmatchedPost(t *testing.T) {
+	h := new(TimingHook)
+
+	addr := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "aws_instance",
+		Name: "foo",
+	}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+
+	// A PostApply with no matching PreApply must not record anything.
+	h.PostApply(addr, states.CurrentGen, cty.NilVal, nil)
+
+	if got := len(h.Timings()); got != 0 {
+		t.Fatalf("wrong number of timings %d; want 0", got)
+	}
+	if got := h.Report(); got != "" {
+		t.Fatalf("wrong report %q; want empty", got)
+	}
+}
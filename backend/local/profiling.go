@@ -0,0 +1,91 @@
+package local
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// startProfiling begins collecting a CPU profile into "cpu.prof" within the
+// given directory, creating the directory first if necessary. The returned
+// function stops the CPU profile and additionally writes a heap profile to
+// "heap.prof" in the same directory, and should be called once the operation
+// has completed.
+//
+// This supports the hidden -profile-dir option on the plan and apply
+// commands, which exists so that users with pathologically-slow
+// configurations can gather actionable data to attach to issue reports.
+func startProfiling(dir string) (stop func(), err error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create profile directory: %s", err)
+	}
+
+	cpuF, err := os.Create(filepath.Join(dir, "cpu.prof"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CPU profile: %s", err)
+	}
+	if err := pprof.StartCPUProfile(cpuF); err != nil {
+		cpuF.Close()
+		return nil, fmt.Errorf("failed to start CPU profile: %s", err)
+	}
+
+	return func() {
+		pprof.StopCPUProfile()
+		cpuF.Close()
+
+		heapF, err := os.Create(filepath.Join(dir, "heap.prof"))
+		if err != nil {
+			log.Printf("[ERROR] backend/local: failed to create heap profile: %s", err)
+			return
+		}
+		defer heapF.Close()
+
+		// Materialize accurate allocation statistics before snapshotting.
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(heapF); err != nil {
+			log.Printf("[ERROR] backend/local: failed to write heap profile: %s", err)
+		}
+	}, nil
+}
+
+// writeGraphSizeReport writes a small plain-text summary of the size and
+// shape of the given graph to "graph-size.txt" in the given directory, as a
+// companion to the pprof profiles collected by startProfiling.
+func writeGraphSizeReport(dir string, g *terraform.Graph) error {
+	f, err := os.Create(filepath.Join(dir, "graph-size.txt"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	vertices := g.Vertices()
+
+	byType := make(map[string]int)
+	for _, v := range vertices {
+		byType[fmt.Sprintf("%T", v)]++
+	}
+
+	fmt.Fprintf(f, "vertices: %d\n", len(vertices))
+	fmt.Fprintf(f, "edges:    %d\n", len(g.Edges()))
+	fmt.Fprintf(f, "\nvertices by type:\n")
+	for _, typeName := range sortedKeys(byType) {
+		fmt.Fprintf(f, "  %6d %s\n", byType[typeName], typeName)
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
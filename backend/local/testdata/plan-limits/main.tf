@@ -0,0 +1,11 @@
+terraform {
+  max_changes = 1
+}
+
+resource "test_instance" "foo" {
+  ami = "bar"
+}
+
+resource "test_instance" "baz" {
+  ami = "qux"
+}
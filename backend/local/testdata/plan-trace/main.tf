@@ -0,0 +1,7 @@
+variable "image" {
+  default = "ami-1234"
+}
+
+resource "test_instance" "foo" {
+  ami = upper(var.image)
+}
@@ -0,0 +1,572 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/rpc"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/internal/tfplugin5"
+	"github.com/hashicorp/terraform/plugin/convert"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/states/statefile"
+	"github.com/hashicorp/terraform/states/statemgr"
+	"github.com/hashicorp/terraform/tfdiags"
+)
+
+// Backend is an implementation of backend.Backend that communicates over
+// RPC with a backend implementation running in a plugin process.
+type Backend struct {
+	Broker *plugin.MuxBroker
+	Client *rpc.Client
+
+	schemaOnce sync.Once
+	schema     *configschema.Block
+}
+
+var _ backend.Backend = (*Backend)(nil)
+
+func (b *Backend) ConfigSchema() *configschema.Block {
+	b.schemaOnce.Do(func() {
+		// The Backend interface gives us nowhere to report errors from
+		// here, so we'll just log them and return an empty schema, which
+		// will then cause downstream validation failures that at least
+		// prevent the unconfigured backend from being used.
+		b.schema = &configschema.Block{}
+
+		var resp BackendConfigSchemaResponse
+		if err := b.Client.Call("Plugin.ConfigSchema", new(interface{}), &resp); err != nil {
+			log.Printf("[ERROR] backend/plugin: failed to retrieve config schema: %s", err)
+			return
+		}
+		var protoSchema tfplugin5.Schema_Block
+		if err := proto.Unmarshal(resp.Schema, &protoSchema); err != nil {
+			log.Printf("[ERROR] backend/plugin: invalid config schema from plugin: %s", err)
+			return
+		}
+		b.schema = convert.ProtoToConfigSchema(&protoSchema)
+	})
+	return b.schema
+}
+
+func (b *Backend) PrepareConfig(obj cty.Value) (cty.Value, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	ty := b.ConfigSchema().ImpliedType()
+
+	src, err := ctyjson.Marshal(obj, ty)
+	if err != nil {
+		diags = diags.Append(err)
+		return obj, diags
+	}
+
+	var resp BackendPrepareConfigResponse
+	if err := b.Client.Call("Plugin.PrepareConfig", &BackendPrepareConfigArgs{ConfigJSON: src}, &resp); err != nil {
+		diags = diags.Append(err)
+		return obj, diags
+	}
+	if resp.Error != nil {
+		diags = diags.Append(resp.Error)
+		return obj, diags
+	}
+
+	newObj, err := ctyjson.Unmarshal(resp.ConfigJSON, ty)
+	if err != nil {
+		diags = diags.Append(err)
+		return obj, diags
+	}
+	return newObj, diags
+}
+
+func (b *Backend) Configure(obj cty.Value) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	ty := b.ConfigSchema().ImpliedType()
+
+	src, err := ctyjson.Marshal(obj, ty)
+	if err != nil {
+		diags = diags.Append(err)
+		return diags
+	}
+
+	var resp BackendConfigureResponse
+	if err := b.Client.Call("Plugin.Configure", &BackendConfigureArgs{ConfigJSON: src}, &resp); err != nil {
+		diags = diags.Append(err)
+		return diags
+	}
+	if resp.Error != nil {
+		diags = diags.Append(resp.Error)
+	}
+	return diags
+}
+
+func (b *Backend) Workspaces() ([]string, error) {
+	var resp BackendWorkspacesResponse
+	if err := b.Client.Call("Plugin.Workspaces", new(interface{}), &resp); err != nil {
+		return nil, err
+	}
+	if resp.NotSupported {
+		return nil, backend.ErrWorkspacesNotSupported
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return resp.Workspaces, nil
+}
+
+func (b *Backend) DeleteWorkspace(name string) error {
+	var resp BackendDeleteWorkspaceResponse
+	if err := b.Client.Call("Plugin.DeleteWorkspace", &BackendDeleteWorkspaceArgs{Name: name}, &resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	return nil
+}
+
+func (b *Backend) StateMgr(name string) (statemgr.Full, error) {
+	var resp BackendStateMgrResponse
+	if err := b.Client.Call("Plugin.StateMgr", &BackendStateMgrArgs{Name: name}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.DefaultWorkspaceNotSupported {
+		return nil, backend.ErrDefaultWorkspaceNotSupported
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return &remoteStateMgr{
+		client: b.Client,
+		name:   name,
+	}, nil
+}
+
+// remoteStateMgr is a statemgr.Full implementation that proxies all of its
+// persistent operations to a state manager in the plugin process, while
+// keeping the usual transient snapshot locally like other state managers do.
+type remoteStateMgr struct {
+	client *rpc.Client
+	name   string
+
+	mu      sync.Mutex
+	state   *states.State
+	lineage string
+	serial  uint64
+
+	// readState is a separate snapshot of the state as of the last refresh
+	// or persist, used to decide whether the serial must be incremented
+	// before the next persist.
+	readState *states.State
+}
+
+var _ statemgr.Full = (*remoteStateMgr)(nil)
+
+func (m *remoteStateMgr) State() *states.State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.DeepCopy()
+}
+
+func (m *remoteStateMgr) WriteState(s *states.State) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = s.DeepCopy()
+	return nil
+}
+
+func (m *remoteStateMgr) RefreshState() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var resp BackendRefreshStateResponse
+	if err := m.client.Call("Plugin.RefreshState", &BackendRefreshStateArgs{Name: m.name}, &resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	if len(resp.StateFile) == 0 {
+		m.state = states.NewState()
+		m.lineage = ""
+		m.serial = 0
+		m.readState = m.state.DeepCopy()
+		return nil
+	}
+
+	f, err := statefile.Read(bytes.NewReader(resp.StateFile))
+	if err != nil {
+		return err
+	}
+	m.state = f.State
+	m.lineage = f.Lineage
+	m.serial = f.Serial
+	m.readState = m.state.DeepCopy() // must be a separate instance so we can track changes
+	return nil
+}
+
+func (m *remoteStateMgr) PersistState() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := m.state
+	if state == nil {
+		state = states.NewState()
+	}
+	if m.lineage == "" {
+		m.lineage = statemgr.NewLineage()
+	}
+
+	// Increment the serial whenever the state has changed since we last
+	// read or wrote a snapshot, so that the plugin can distinguish
+	// successive snapshots, in the same way as the other persistent state
+	// managers do.
+	if m.readState != nil {
+		if statefile.StatesMarshalEqual(state, m.readState) {
+			// State hasn't changed at all, so nothing to do.
+			return nil
+		}
+		m.serial++
+	}
+
+	var buf bytes.Buffer
+	if err := statefile.Write(statefile.New(state, m.lineage, m.serial), &buf); err != nil {
+		return err
+	}
+
+	var resp BackendPersistStateResponse
+	if err := m.client.Call("Plugin.PersistState", &BackendPersistStateArgs{Name: m.name, StateFile: buf.Bytes()}, &resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	m.readState = state.DeepCopy()
+	return nil
+}
+
+func (m *remoteStateMgr) Lock(info *statemgr.LockInfo) (string, error) {
+	var resp BackendLockStateResponse
+	if err := m.client.Call("Plugin.LockState", &BackendLockStateArgs{Name: m.name, Info: info.Marshal()}, &resp); err != nil {
+		return "", err
+	}
+	if resp.Error == nil {
+		return resp.ID, nil
+	}
+
+	// If the plugin reported a lock conflict then we'll reconstruct the
+	// statemgr.LockError that callers expect, so that features like
+	// -force-unlock can still see who holds the lock.
+	if len(resp.LockInfo) != 0 {
+		lockInfo := &statemgr.LockInfo{}
+		if err := json.Unmarshal(resp.LockInfo, lockInfo); err == nil {
+			return "", &statemgr.LockError{
+				Info: lockInfo,
+				Err:  resp.Error,
+			}
+		}
+	}
+	return "", resp.Error
+}
+
+func (m *remoteStateMgr) Unlock(id string) error {
+	var resp BackendUnlockStateResponse
+	if err := m.client.Call("Plugin.UnlockState", &BackendUnlockStateArgs{Name: m.name, ID: id}, &resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	return nil
+}
+
+type BackendConfigSchemaResponse struct {
+	Schema []byte // a protobuf-serialized tfplugin5.Schema_Block
+}
+
+type BackendPrepareConfigArgs struct {
+	ConfigJSON []byte
+}
+
+type BackendPrepareConfigResponse struct {
+	ConfigJSON []byte
+	Error      *plugin.BasicError
+}
+
+type BackendConfigureArgs struct {
+	ConfigJSON []byte
+}
+
+type BackendConfigureResponse struct {
+	Error *plugin.BasicError
+}
+
+type BackendWorkspacesResponse struct {
+	Workspaces   []string
+	NotSupported bool
+	Error        *plugin.BasicError
+}
+
+type BackendDeleteWorkspaceArgs struct {
+	Name string
+}
+
+type BackendDeleteWorkspaceResponse struct {
+	Error *plugin.BasicError
+}
+
+type BackendStateMgrArgs struct {
+	Name string
+}
+
+type BackendStateMgrResponse struct {
+	DefaultWorkspaceNotSupported bool
+	Error                        *plugin.BasicError
+}
+
+type BackendRefreshStateArgs struct {
+	Name string
+}
+
+type BackendRefreshStateResponse struct {
+	StateFile []byte
+	Error     *plugin.BasicError
+}
+
+type BackendPersistStateArgs struct {
+	Name      string
+	StateFile []byte
+}
+
+type BackendPersistStateResponse struct {
+	Error *plugin.BasicError
+}
+
+type BackendLockStateArgs struct {
+	Name string
+	Info []byte // a JSON-serialized statemgr.LockInfo
+}
+
+type BackendLockStateResponse struct {
+	ID       string
+	LockInfo []byte // a JSON-serialized statemgr.LockInfo, on lock conflict
+	Error    *plugin.BasicError
+}
+
+type BackendUnlockStateArgs struct {
+	Name string
+	ID   string
+}
+
+type BackendUnlockStateResponse struct {
+	Error *plugin.BasicError
+}
+
+// BackendServer is a net/rpc compatible structure for serving a backend.
+// This should not be used directly.
+type BackendServer struct {
+	Broker  *plugin.MuxBroker
+	Backend backend.Backend
+
+	mu        sync.Mutex
+	stateMgrs map[string]statemgr.Full
+}
+
+func (s *BackendServer) ConfigSchema(args interface{}, reply *BackendConfigSchemaResponse) error {
+	protoSchema := convert.ConfigSchemaToProto(s.Backend.ConfigSchema())
+	src, err := proto.Marshal(protoSchema)
+	if err != nil {
+		return err
+	}
+	reply.Schema = src
+	return nil
+}
+
+func (s *BackendServer) PrepareConfig(args *BackendPrepareConfigArgs, reply *BackendPrepareConfigResponse) error {
+	ty := s.Backend.ConfigSchema().ImpliedType()
+	obj, err := ctyjson.Unmarshal(args.ConfigJSON, ty)
+	if err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		return nil
+	}
+
+	newObj, diags := s.Backend.PrepareConfig(obj)
+	if diags.HasErrors() {
+		reply.Error = plugin.NewBasicError(diags.Err())
+		return nil
+	}
+
+	src, err := ctyjson.Marshal(newObj, ty)
+	if err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		return nil
+	}
+	reply.ConfigJSON = src
+	return nil
+}
+
+func (s *BackendServer) Configure(args *BackendConfigureArgs, reply *BackendConfigureResponse) error {
+	ty := s.Backend.ConfigSchema().ImpliedType()
+	obj, err := ctyjson.Unmarshal(args.ConfigJSON, ty)
+	if err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		return nil
+	}
+
+	if diags := s.Backend.Configure(obj); diags.HasErrors() {
+		reply.Error = plugin.NewBasicError(diags.Err())
+	}
+	return nil
+}
+
+func (s *BackendServer) Workspaces(args interface{}, reply *BackendWorkspacesResponse) error {
+	workspaces, err := s.Backend.Workspaces()
+	switch {
+	case err == backend.ErrWorkspacesNotSupported:
+		reply.NotSupported = true
+	case err != nil:
+		reply.Error = plugin.NewBasicError(err)
+	default:
+		reply.Workspaces = workspaces
+	}
+	return nil
+}
+
+func (s *BackendServer) DeleteWorkspace(args *BackendDeleteWorkspaceArgs, reply *BackendDeleteWorkspaceResponse) error {
+	if err := s.Backend.DeleteWorkspace(args.Name); err != nil {
+		reply.Error = plugin.NewBasicError(err)
+	}
+	return nil
+}
+
+func (s *BackendServer) StateMgr(args *BackendStateMgrArgs, reply *BackendStateMgrResponse) error {
+	_, err := s.stateMgr(args.Name)
+	switch {
+	case err == backend.ErrDefaultWorkspaceNotSupported:
+		reply.DefaultWorkspaceNotSupported = true
+	case err != nil:
+		reply.Error = plugin.NewBasicError(err)
+	}
+	return nil
+}
+
+func (s *BackendServer) RefreshState(args *BackendRefreshStateArgs, reply *BackendRefreshStateResponse) error {
+	mgr, err := s.stateMgr(args.Name)
+	if err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		return nil
+	}
+
+	if err := mgr.RefreshState(); err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		return nil
+	}
+	state := mgr.State()
+	if state == nil {
+		return nil // no state snapshot yet, so no StateFile in the reply
+	}
+
+	// Export preserves the manager's snapshot metadata (serial and lineage)
+	// where the manager supports it, so that the client sees the same
+	// metadata it would when using the backend directly.
+	var buf bytes.Buffer
+	if err := statefile.Write(statemgr.Export(mgr), &buf); err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		return nil
+	}
+	reply.StateFile = buf.Bytes()
+	return nil
+}
+
+func (s *BackendServer) PersistState(args *BackendPersistStateArgs, reply *BackendPersistStateResponse) error {
+	mgr, err := s.stateMgr(args.Name)
+	if err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		return nil
+	}
+
+	f, err := statefile.Read(bytes.NewReader(args.StateFile))
+	if err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		return nil
+	}
+	// Import carries the client's snapshot metadata (serial and lineage)
+	// into the manager where it supports that, rather than discarding it
+	// as a plain WriteState would. The client is authoritative here, so we
+	// force the write rather than checking for lineage conflicts.
+	if err := statemgr.Import(f, mgr, true); err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		return nil
+	}
+	if err := mgr.PersistState(); err != nil {
+		reply.Error = plugin.NewBasicError(err)
+	}
+	return nil
+}
+
+func (s *BackendServer) LockState(args *BackendLockStateArgs, reply *BackendLockStateResponse) error {
+	mgr, err := s.stateMgr(args.Name)
+	if err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		return nil
+	}
+
+	info := &statemgr.LockInfo{}
+	if err := json.Unmarshal(args.Info, info); err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		return nil
+	}
+
+	id, err := mgr.Lock(info)
+	if err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		if le, ok := err.(*statemgr.LockError); ok && le.Info != nil {
+			reply.LockInfo = le.Info.Marshal()
+		}
+		return nil
+	}
+	reply.ID = id
+	return nil
+}
+
+func (s *BackendServer) UnlockState(args *BackendUnlockStateArgs, reply *BackendUnlockStateResponse) error {
+	mgr, err := s.stateMgr(args.Name)
+	if err != nil {
+		reply.Error = plugin.NewBasicError(err)
+		return nil
+	}
+
+	if err := mgr.Unlock(args.ID); err != nil {
+		reply.Error = plugin.NewBasicError(err)
+	}
+	return nil
+}
+
+// stateMgr returns the real state manager for the given workspace, creating
+// and caching it on first use so that transient state and locks held by the
+// manager survive across requests.
+func (s *BackendServer) stateMgr(name string) (statemgr.Full, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if mgr, exists := s.stateMgrs[name]; exists {
+		return mgr, nil
+	}
+
+	mgr, err := s.Backend.StateMgr(name)
+	if err != nil {
+		return nil, err
+	}
+	if s.stateMgrs == nil {
+		s.stateMgrs = map[string]statemgr.Full{}
+	}
+	s.stateMgrs[name] = mgr
+	return mgr, nil
+}
@@ -0,0 +1,94 @@
+// Package plugin implements an experimental protocol for running Terraform
+// state storage backends as external plugin processes, using the same
+// go-plugin infrastructure that Terraform uses for providers and
+// provisioners.
+//
+// This protocol is experimental: it may change in incompatible ways in any
+// future release. It also has some known limitations compared to built-in
+// backends. In particular, diagnostics returned from PrepareConfig and
+// Configure are flattened into plain error messages when they cross the
+// plugin boundary, losing any source location information.
+//
+// A backend served over this protocol can be validated against the same
+// requirements as the built-in backends by running the conformance suite
+// in package backendtest against a connected client.
+package plugin
+
+import (
+	"net/rpc"
+	"os/exec"
+
+	plugin "github.com/hashicorp/go-plugin"
+
+	"github.com/hashicorp/terraform/backend"
+)
+
+// Handshake is the HandshakeConfig used by backend plugin clients and
+// servers.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "TF_BACKEND_PLUGIN_MAGIC_COOKIE",
+	MagicCookieValue: "5fdf3cfca0d6be24f5c7b74b1c1394a3ae09cf4a8f85d2fd34f7f6a0a2cb9f76",
+}
+
+// BackendPluginName is the name of backend plugins within the plugin map
+// passed to go-plugin.
+const BackendPluginName = "backend"
+
+// BackendPlugin is the plugin.Plugin implementation for backends.
+type BackendPlugin struct {
+	Backend func() backend.Backend
+}
+
+func (p *BackendPlugin) Server(b *plugin.MuxBroker) (interface{}, error) {
+	return &BackendServer{
+		Broker:  b,
+		Backend: p.Backend(),
+	}, nil
+}
+
+func (p *BackendPlugin) Client(b *plugin.MuxBroker, c *rpc.Client) (interface{}, error) {
+	return &Backend{Broker: b, Client: c}, nil
+}
+
+// Serve serves the backend produced by the given function as an external
+// plugin, for use in the main function of a backend plugin executable.
+// It does not return until the host process asks the plugin to exit.
+func Serve(f func() backend.Backend) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins: map[string]plugin.Plugin{
+			BackendPluginName: &BackendPlugin{Backend: f},
+		},
+	})
+}
+
+// Connect launches the backend plugin executable at the given path and
+// returns a Backend that proxies all operations to it.
+//
+// The second return value is a function that must be called once the
+// backend is no longer needed, to terminate the child process.
+func Connect(path string) (backend.Backend, func(), error) {
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig: Handshake,
+		Plugins: map[string]plugin.Plugin{
+			BackendPluginName: &BackendPlugin{},
+		},
+		Cmd:              exec.Command(path),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolNetRPC},
+	})
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, nil, err
+	}
+
+	raw, err := rpcClient.Dispense(BackendPluginName)
+	if err != nil {
+		client.Kill()
+		return nil, nil, err
+	}
+
+	return raw.(backend.Backend), client.Kill, nil
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/configs"
 	"github.com/hashicorp/terraform/state/remote"
 	"github.com/zclconf/go-cty/cty"
@@ -28,7 +29,7 @@ func TestArtifactoryFactory(t *testing.T) {
 	config["username"] = cty.StringVal("test")
 	config["password"] = cty.StringVal("testpass")
 
-	b := backend.TestBackendConfig(t, New(), configs.SynthBody("synth", config))
+	b := backendtest.TestBackendConfig(t, New(), configs.SynthBody("synth", config))
 
 	state, err := b.StateMgr(backend.DefaultStateName)
 	if err != nil {
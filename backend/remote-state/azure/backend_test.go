@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/helper/acctest"
 )
 
@@ -25,7 +26,7 @@ func TestBackendConfig(t *testing.T) {
 		"access_key": "QUNDRVNTX0tFWQ0K",
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(config)).(*Backend)
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(config)).(*Backend)
 
 	if b.containerName != "tfcontainer" {
 		t.Fatalf("Incorrect bucketName was populated")
@@ -49,7 +50,7 @@ func TestBackendAccessKeyBasic(t *testing.T) {
 		t.Fatalf("Error creating Test Resources: %q", err)
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"storage_account_name": res.storageAccountName,
 		"container_name":       res.storageContainerName,
 		"key":                  res.storageKeyName,
@@ -58,7 +59,7 @@ func TestBackendAccessKeyBasic(t *testing.T) {
 		"endpoint":             os.Getenv("ARM_ENDPOINT"),
 	})).(*Backend)
 
-	backend.TestBackendStates(t, b)
+	backendtest.TestBackendStates(t, b)
 }
 
 func TestBackendManagedServiceIdentityBasic(t *testing.T) {
@@ -74,7 +75,7 @@ func TestBackendManagedServiceIdentityBasic(t *testing.T) {
 		t.Fatalf("Error creating Test Resources: %q", err)
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"storage_account_name": res.storageAccountName,
 		"container_name":       res.storageContainerName,
 		"key":                  res.storageKeyName,
@@ -86,7 +87,7 @@ func TestBackendManagedServiceIdentityBasic(t *testing.T) {
 		"endpoint":             os.Getenv("ARM_ENDPOINT"),
 	})).(*Backend)
 
-	backend.TestBackendStates(t, b)
+	backendtest.TestBackendStates(t, b)
 }
 
 func TestBackendSASTokenBasic(t *testing.T) {
@@ -107,7 +108,7 @@ func TestBackendSASTokenBasic(t *testing.T) {
 		t.Fatalf("Error building SAS Token: %+v", err)
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"storage_account_name": res.storageAccountName,
 		"container_name":       res.storageContainerName,
 		"key":                  res.storageKeyName,
@@ -116,7 +117,7 @@ func TestBackendSASTokenBasic(t *testing.T) {
 		"endpoint":             os.Getenv("ARM_ENDPOINT"),
 	})).(*Backend)
 
-	backend.TestBackendStates(t, b)
+	backendtest.TestBackendStates(t, b)
 }
 
 func TestBackendServicePrincipalBasic(t *testing.T) {
@@ -132,7 +133,7 @@ func TestBackendServicePrincipalBasic(t *testing.T) {
 		t.Fatalf("Error creating Test Resources: %q", err)
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"storage_account_name": res.storageAccountName,
 		"container_name":       res.storageContainerName,
 		"key":                  res.storageKeyName,
@@ -145,7 +146,7 @@ func TestBackendServicePrincipalBasic(t *testing.T) {
 		"endpoint":             os.Getenv("ARM_ENDPOINT"),
 	})).(*Backend)
 
-	backend.TestBackendStates(t, b)
+	backendtest.TestBackendStates(t, b)
 }
 
 func TestBackendServicePrincipalCustomEndpoint(t *testing.T) {
@@ -168,7 +169,7 @@ func TestBackendServicePrincipalCustomEndpoint(t *testing.T) {
 		t.Fatalf("Error creating Test Resources: %q", err)
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"storage_account_name": res.storageAccountName,
 		"container_name":       res.storageContainerName,
 		"key":                  res.storageKeyName,
@@ -181,7 +182,7 @@ func TestBackendServicePrincipalCustomEndpoint(t *testing.T) {
 		"endpoint":             endpoint,
 	})).(*Backend)
 
-	backend.TestBackendStates(t, b)
+	backendtest.TestBackendStates(t, b)
 }
 
 func TestBackendAccessKeyLocked(t *testing.T) {
@@ -197,7 +198,7 @@ func TestBackendAccessKeyLocked(t *testing.T) {
 		t.Fatalf("Error creating Test Resources: %q", err)
 	}
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"storage_account_name": res.storageAccountName,
 		"container_name":       res.storageContainerName,
 		"key":                  res.storageKeyName,
@@ -206,7 +207,7 @@ func TestBackendAccessKeyLocked(t *testing.T) {
 		"endpoint":             os.Getenv("ARM_ENDPOINT"),
 	})).(*Backend)
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"storage_account_name": res.storageAccountName,
 		"container_name":       res.storageContainerName,
 		"key":                  res.storageKeyName,
@@ -215,8 +216,8 @@ func TestBackendAccessKeyLocked(t *testing.T) {
 		"endpoint":             os.Getenv("ARM_ENDPOINT"),
 	})).(*Backend)
 
-	backend.TestBackendStateLocks(t, b1, b2)
-	backend.TestBackendStateForceUnlock(t, b1, b2)
+	backendtest.TestBackendStateLocks(t, b1, b2)
+	backendtest.TestBackendStateForceUnlock(t, b1, b2)
 }
 
 func TestBackendServicePrincipalLocked(t *testing.T) {
@@ -232,7 +233,7 @@ func TestBackendServicePrincipalLocked(t *testing.T) {
 		t.Fatalf("Error creating Test Resources: %q", err)
 	}
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"storage_account_name": res.storageAccountName,
 		"container_name":       res.storageContainerName,
 		"key":                  res.storageKeyName,
@@ -245,7 +246,7 @@ func TestBackendServicePrincipalLocked(t *testing.T) {
 		"endpoint":             os.Getenv("ARM_ENDPOINT"),
 	})).(*Backend)
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"storage_account_name": res.storageAccountName,
 		"container_name":       res.storageContainerName,
 		"key":                  res.storageKeyName,
@@ -258,6 +259,6 @@ func TestBackendServicePrincipalLocked(t *testing.T) {
 		"endpoint":             os.Getenv("ARM_ENDPOINT"),
 	})).(*Backend)
 
-	backend.TestBackendStateLocks(t, b1, b2)
-	backend.TestBackendStateForceUnlock(t, b1, b2)
+	backendtest.TestBackendStateLocks(t, b1, b2)
+	backendtest.TestBackendStateForceUnlock(t, b1, b2)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/consul/testutil"
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 )
 
 func TestBackend_impl(t *testing.T) {
@@ -52,50 +53,50 @@ func TestBackend(t *testing.T) {
 	path := fmt.Sprintf("tf-unit/%s", time.Now().String())
 
 	// Get the backend. We need two to test locking.
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"address": srv.HTTPAddr,
 		"path":    path,
 	}))
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"address": srv.HTTPAddr,
 		"path":    path,
 	}))
 
 	// Test
-	backend.TestBackendStates(t, b1)
-	backend.TestBackendStateLocks(t, b1, b2)
+	backendtest.TestBackendStates(t, b1)
+	backendtest.TestBackendStateLocks(t, b1, b2)
 }
 
 func TestBackend_lockDisabled(t *testing.T) {
 	path := fmt.Sprintf("tf-unit/%s", time.Now().String())
 
 	// Get the backend. We need two to test locking.
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"address": srv.HTTPAddr,
 		"path":    path,
 		"lock":    false,
 	}))
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"address": srv.HTTPAddr,
 		"path":    path + "different", // Diff so locking test would fail if it was locking
 		"lock":    false,
 	}))
 
 	// Test
-	backend.TestBackendStates(t, b1)
-	backend.TestBackendStateLocks(t, b1, b2)
+	backendtest.TestBackendStates(t, b1)
+	backendtest.TestBackendStateLocks(t, b1, b2)
 }
 
 func TestBackend_gzip(t *testing.T) {
 	// Get the backend
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"address": srv.HTTPAddr,
 		"path":    fmt.Sprintf("tf-unit/%s", time.Now().String()),
 		"gzip":    true,
 	}))
 
 	// Test
-	backend.TestBackendStates(t, b)
+	backendtest.TestBackendStates(t, b)
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/state/remote"
 	"github.com/likexian/gokit/assert"
 )
@@ -141,9 +142,9 @@ func TestBackend(t *testing.T) {
 	be1 := setupBackend(t, bucket, defaultPrefix, defaultKey, false)
 	defer teardownBackend(t, be1)
 
-	backend.TestBackendStates(t, be0)
-	backend.TestBackendStateLocks(t, be0, be1)
-	backend.TestBackendStateForceUnlock(t, be0, be1)
+	backendtest.TestBackendStates(t, be0)
+	backendtest.TestBackendStateLocks(t, be0, be1)
+	backendtest.TestBackendStateForceUnlock(t, be0, be1)
 }
 
 func TestBackendWithPrefix(t *testing.T) {
@@ -158,8 +159,8 @@ func TestBackendWithPrefix(t *testing.T) {
 	be1 := setupBackend(t, bucket, prefix+"/", defaultKey, false)
 	defer teardownBackend(t, be1)
 
-	backend.TestBackendStates(t, be0)
-	backend.TestBackendStateLocks(t, be0, be1)
+	backendtest.TestBackendStates(t, be0)
+	backendtest.TestBackendStateLocks(t, be0, be1)
 }
 
 func TestBackendWithEncryption(t *testing.T) {
@@ -173,8 +174,8 @@ func TestBackendWithEncryption(t *testing.T) {
 	be1 := setupBackend(t, bucket, defaultPrefix, defaultKey, true)
 	defer teardownBackend(t, be1)
 
-	backend.TestBackendStates(t, be0)
-	backend.TestBackendStateLocks(t, be0, be1)
+	backendtest.TestBackendStates(t, be0)
+	backendtest.TestBackendStateLocks(t, be0, be1)
 }
 
 func setupBackend(t *testing.T, bucket, prefix, key string, encrypt bool) backend.Backend {
@@ -199,7 +200,7 @@ func setupBackend(t *testing.T, bucket, prefix, key string, encrypt bool) backen
 		"key":    key,
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(config))
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(config))
 	be := b.(*Backend)
 
 	c, err := be.client("tencentcloud")
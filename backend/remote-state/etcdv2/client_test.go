@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/configs"
 	"github.com/hashicorp/terraform/state/remote"
 	"github.com/zclconf/go-cty/cty"
@@ -35,7 +36,7 @@ func TestEtcdClient(t *testing.T) {
 		config["password"] = cty.StringVal(password)
 	}
 
-	b := backend.TestBackendConfig(t, New(), configs.SynthBody("synth", config))
+	b := backendtest.TestBackendConfig(t, New(), configs.SynthBody("synth", config))
 	state, err := b.StateMgr(backend.DefaultStateName)
 	if err != nil {
 		t.Fatalf("Error for valid config: %s", err)
@@ -11,6 +11,7 @@ import (
 
 	etcdv3 "github.com/coreos/etcd/clientv3"
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 )
 
 var (
@@ -59,20 +60,20 @@ func TestBackend(t *testing.T) {
 	prefix := fmt.Sprintf("%s/%s/", keyPrefix, time.Now().Format(time.RFC3339))
 
 	// Get the backend. We need two to test locking.
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"endpoints": etcdv3Endpoints,
 		"prefix":    prefix,
 	}))
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"endpoints": etcdv3Endpoints,
 		"prefix":    prefix,
 	}))
 
 	// Test
-	backend.TestBackendStates(t, b1)
-	backend.TestBackendStateLocks(t, b1, b2)
-	backend.TestBackendStateForceUnlock(t, b1, b2)
+	backendtest.TestBackendStates(t, b1)
+	backendtest.TestBackendStateLocks(t, b1, b2)
+	backendtest.TestBackendStateForceUnlock(t, b1, b2)
 }
 
 func TestBackend_lockDisabled(t *testing.T) {
@@ -82,18 +83,18 @@ func TestBackend_lockDisabled(t *testing.T) {
 	prefix := fmt.Sprintf("%s/%s/", keyPrefix, time.Now().Format(time.RFC3339))
 
 	// Get the backend. We need two to test locking.
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"endpoints": etcdv3Endpoints,
 		"prefix":    prefix,
 		"lock":      false,
 	}))
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"endpoints": etcdv3Endpoints,
 		"prefix":    prefix + "/" + "different", // Diff so locking test would fail if it was locking
 		"lock":      false,
 	}))
 
 	// Test
-	backend.TestBackendStateLocks(t, b1, b2)
+	backendtest.TestBackendStateLocks(t, b1, b2)
 }
@@ -10,6 +10,7 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/state/remote"
 )
 
@@ -136,9 +137,9 @@ func TestBackend(t *testing.T) {
 
 	be1 := setupBackend(t, bucket, noPrefix, noEncryptionKey)
 
-	backend.TestBackendStates(t, be0)
-	backend.TestBackendStateLocks(t, be0, be1)
-	backend.TestBackendStateForceUnlock(t, be0, be1)
+	backendtest.TestBackendStates(t, be0)
+	backendtest.TestBackendStateLocks(t, be0, be1)
+	backendtest.TestBackendStateForceUnlock(t, be0, be1)
 }
 
 func TestBackendWithPrefix(t *testing.T) {
@@ -152,8 +153,8 @@ func TestBackendWithPrefix(t *testing.T) {
 
 	be1 := setupBackend(t, bucket, prefix+"/", noEncryptionKey)
 
-	backend.TestBackendStates(t, be0)
-	backend.TestBackendStateLocks(t, be0, be1)
+	backendtest.TestBackendStates(t, be0)
+	backendtest.TestBackendStateLocks(t, be0, be1)
 }
 func TestBackendWithEncryption(t *testing.T) {
 	t.Parallel()
@@ -165,8 +166,8 @@ func TestBackendWithEncryption(t *testing.T) {
 
 	be1 := setupBackend(t, bucket, noPrefix, encryptionKey)
 
-	backend.TestBackendStates(t, be0)
-	backend.TestBackendStateLocks(t, be0, be1)
+	backendtest.TestBackendStates(t, be0)
+	backendtest.TestBackendStateLocks(t, be0, be1)
 }
 
 // setupBackend returns a new GCS backend.
@@ -186,7 +187,7 @@ func setupBackend(t *testing.T, bucket, prefix, key string) backend.Backend {
 		"encryption_key": key,
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(config))
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(config))
 	be := b.(*Backend)
 
 	// create the bucket if it doesn't exist
@@ -8,6 +8,7 @@ import (
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 )
 
 func TestBackend_impl(t *testing.T) {
@@ -20,7 +21,7 @@ func TestHTTPClientFactory(t *testing.T) {
 	conf := map[string]cty.Value{
 		"address": cty.StringVal("http://127.0.0.1:8888/foo"),
 	}
-	b := backend.TestBackendConfig(t, New(), configs.SynthBody("synth", conf)).(*Backend)
+	b := backendtest.TestBackendConfig(t, New(), configs.SynthBody("synth", conf)).(*Backend)
 	client := b.client
 
 	if client == nil {
@@ -57,7 +58,7 @@ func TestHTTPClientFactory(t *testing.T) {
 		"retry_wait_max": cty.StringVal("150"),
 	}
 
-	b = backend.TestBackendConfig(t, New(), configs.SynthBody("synth", conf)).(*Backend)
+	b = backendtest.TestBackendConfig(t, New(), configs.SynthBody("synth", conf)).(*Backend)
 	client = b.client
 
 	if client == nil {
@@ -36,7 +36,7 @@ func Reset() {
 	}
 
 	locks = lockMap{
-		m: map[string]*state.LockInfo{},
+		m: map[string][]*state.LockInfo{},
 	}
 }
 
@@ -155,20 +155,24 @@ type stateMap struct {
 	m map[string]*remote.State
 }
 
-// Global level locks for inmem backends.
+// Global level locks for inmem backends. Multiple read locks may be held
+// on a state at once, but a write lock is exclusive.
 type lockMap struct {
 	sync.Mutex
-	m map[string]*state.LockInfo
+	m map[string][]*state.LockInfo
 }
 
 func (l *lockMap) lock(name string, info *state.LockInfo) (string, error) {
 	l.Lock()
 	defer l.Unlock()
 
-	lockInfo := l.m[name]
-	if lockInfo != nil {
+	for _, lockInfo := range l.m[name] {
+		if info.SharedWith(lockInfo) {
+			continue
+		}
+
 		lockErr := &state.LockError{
-			Info: lockInfo,
+			Info: &state.LockInfo{},
 		}
 
 		lockErr.Err = errors.New("state locked")
@@ -178,7 +182,7 @@ func (l *lockMap) lock(name string, info *state.LockInfo) (string, error) {
 	}
 
 	info.Created = time.Now().UTC()
-	l.m[name] = info
+	l.m[name] = append(l.m[name], info)
 
 	return info.ID, nil
 }
@@ -187,22 +191,26 @@ func (l *lockMap) unlock(name, id string) error {
 	l.Lock()
 	defer l.Unlock()
 
-	lockInfo := l.m[name]
+	lockInfos := l.m[name]
 
-	if lockInfo == nil {
+	if len(lockInfos) == 0 {
 		return errors.New("state not locked")
 	}
 
-	lockErr := &state.LockError{
-		Info: &state.LockInfo{},
+	for i, lockInfo := range lockInfos {
+		if id == lockInfo.ID {
+			l.m[name] = append(lockInfos[:i], lockInfos[i+1:]...)
+			if len(l.m[name]) == 0 {
+				delete(l.m, name)
+			}
+			return nil
+		}
 	}
 
-	if id != lockInfo.ID {
-		lockErr.Err = errors.New("invalid lock id")
-		*lockErr.Info = *lockInfo
-		return lockErr
+	lockErr := &state.LockError{
+		Info: &state.LockInfo{},
 	}
-
-	delete(l.m, name)
-	return nil
+	lockErr.Err = errors.New("invalid lock id")
+	*lockErr.Info = *lockInfos[0]
+	return lockErr
 }
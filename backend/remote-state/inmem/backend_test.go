@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/helper/logging"
 	"github.com/hashicorp/terraform/state/remote"
 	statespkg "github.com/hashicorp/terraform/states"
@@ -41,7 +42,7 @@ func TestBackendConfig(t *testing.T) {
 		"lock_id": testID,
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(config)).(*Backend)
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(config)).(*Backend)
 
 	s, err := b.StateMgr(backend.DefaultStateName)
 	if err != nil {
@@ -60,22 +61,22 @@ func TestBackendConfig(t *testing.T) {
 
 func TestBackend(t *testing.T) {
 	defer Reset()
-	b := backend.TestBackendConfig(t, New(), hcl.EmptyBody()).(*Backend)
-	backend.TestBackendStates(t, b)
+	b := backendtest.TestBackendConfig(t, New(), hcl.EmptyBody()).(*Backend)
+	backendtest.TestBackendStates(t, b)
 }
 
 func TestBackendLocked(t *testing.T) {
 	defer Reset()
-	b1 := backend.TestBackendConfig(t, New(), hcl.EmptyBody()).(*Backend)
-	b2 := backend.TestBackendConfig(t, New(), hcl.EmptyBody()).(*Backend)
+	b1 := backendtest.TestBackendConfig(t, New(), hcl.EmptyBody()).(*Backend)
+	b2 := backendtest.TestBackendConfig(t, New(), hcl.EmptyBody()).(*Backend)
 
-	backend.TestBackendStateLocks(t, b1, b2)
+	backendtest.TestBackendStateLocks(t, b1, b2)
 }
 
 // use the this backen to test the remote.State implementation
 func TestRemoteState(t *testing.T) {
 	defer Reset()
-	b := backend.TestBackendConfig(t, New(), hcl.EmptyBody())
+	b := backendtest.TestBackendConfig(t, New(), hcl.EmptyBody())
 
 	workspace := "workspace"
 
@@ -5,6 +5,8 @@ import (
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
+	"github.com/hashicorp/terraform/state"
 	"github.com/hashicorp/terraform/state/remote"
 )
 
@@ -15,7 +17,7 @@ func TestRemoteClient_impl(t *testing.T) {
 
 func TestRemoteClient(t *testing.T) {
 	defer Reset()
-	b := backend.TestBackendConfig(t, New(), hcl.EmptyBody())
+	b := backendtest.TestBackendConfig(t, New(), hcl.EmptyBody())
 
 	s, err := b.StateMgr(backend.DefaultStateName)
 	if err != nil {
@@ -27,10 +29,72 @@ func TestRemoteClient(t *testing.T) {
 
 func TestInmemLocks(t *testing.T) {
 	defer Reset()
-	s, err := backend.TestBackendConfig(t, New(), hcl.EmptyBody()).StateMgr(backend.DefaultStateName)
+	s, err := backendtest.TestBackendConfig(t, New(), hcl.EmptyBody()).StateMgr(backend.DefaultStateName)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	remote.TestRemoteLocks(t, s.(*remote.State).Client, s.(*remote.State).Client)
 }
+
+func TestInmemLocks_readShared(t *testing.T) {
+	defer Reset()
+	s, err := backendtest.TestBackendConfig(t, New(), hcl.EmptyBody()).StateMgr(backend.DefaultStateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := s.(*remote.State).Client.(*RemoteClient)
+
+	infoA := state.NewLockInfo()
+	infoA.Operation = "show"
+	infoA.Mode = state.LockModeRead
+
+	infoB := state.NewLockInfo()
+	infoB.Operation = "show"
+	infoB.Mode = state.LockModeRead
+
+	// two read locks may be held at once
+	idA, err := client.Lock(infoA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idB, err := client.Lock(infoB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// but a write lock conflicts with them
+	infoC := state.NewLockInfo()
+	infoC.Operation = "apply"
+	if _, err := client.Lock(infoC); err == nil {
+		t.Fatal("expected write lock to conflict with read locks")
+	}
+
+	if err := client.Unlock(idA); err != nil {
+		t.Fatal(err)
+	}
+
+	// a write lock still conflicts with the remaining read lock
+	if _, err := client.Lock(infoC); err == nil {
+		t.Fatal("expected write lock to conflict with remaining read lock")
+	}
+
+	if err := client.Unlock(idB); err != nil {
+		t.Fatal(err)
+	}
+
+	// with all read locks released the write lock can be taken, and
+	// excludes new read locks
+	idC, err := client.Lock(infoC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Lock(infoA); err == nil {
+		t.Fatal("expected read lock to conflict with write lock")
+	}
+
+	if err := client.Unlock(idC); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,175 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// apiClient is a minimal Kubernetes API client covering only the operations
+// on Secrets and Leases that this backend needs. We talk to the API server
+// directly rather than depending on the full Kubernetes client libraries.
+type apiClient struct {
+	// BaseURL is the root URL of the API server, e.g. https://10.0.0.1:6443
+	BaseURL *url.URL
+
+	// HTTPClient is configured with any TLS settings from the backend
+	// configuration or kubeconfig.
+	HTTPClient *http.Client
+
+	// BearerToken is sent as Authorization: Bearer when set.
+	BearerToken string
+
+	// Username and Password are used for HTTP basic auth when set.
+	Username string
+	Password string
+}
+
+// apiStatus is the Kubernetes Status object returned with error responses.
+type apiStatus struct {
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+	Code    int    `json:"code"`
+}
+
+// apiError represents a non-2xx response from the API server.
+type apiError struct {
+	StatusCode int
+	Status     apiStatus
+}
+
+func (e *apiError) Error() string {
+	if e.Status.Message != "" {
+		return fmt.Sprintf("Kubernetes API error (HTTP %d): %s", e.StatusCode, e.Status.Message)
+	}
+	return fmt.Sprintf("Kubernetes API error (HTTP %d)", e.StatusCode)
+}
+
+// isNotFound returns true if the given error is an apiError for a 404
+// response.
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*apiError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// isConflict returns true if the given error is an apiError for a 409
+// response, which the API server returns both for creation of an object
+// that already exists and for updates with a stale resourceVersion.
+func isConflict(err error) bool {
+	apiErr, ok := err.(*apiError)
+	return ok && apiErr.StatusCode == http.StatusConflict
+}
+
+// do performs a single API request. A non-nil body is sent as JSON, and a
+// non-nil out receives the decoded JSON response. Non-2xx responses are
+// returned as an *apiError.
+func (c *apiClient) do(method, apiPath string, query url.Values, body, out interface{}) error {
+	reqURL := *c.BaseURL
+	reqURL.Path = path.Join(reqURL.Path, apiPath)
+	if query != nil {
+		reqURL.RawQuery = query.Encode()
+	}
+
+	var reqBody *bytes.Reader
+	if body != nil {
+		js, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(js)
+	} else {
+		reqBody = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, reqURL.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.BearerToken)
+	} else if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &apiError{StatusCode: resp.StatusCode}
+		// the body is a Status object on a best-effort basis
+		json.Unmarshal(respBody, &apiErr.Status)
+		return apiErr
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to decode Kubernetes API response: %s", err)
+		}
+	}
+	return nil
+}
+
+// objectMeta is the subset of Kubernetes object metadata used by this
+// backend.
+type objectMeta struct {
+	Name            string            `json:"name,omitempty"`
+	Namespace       string            `json:"namespace,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+	ResourceVersion string            `json:"resourceVersion,omitempty"`
+}
+
+// secret is a Kubernetes v1 Secret. Data values are automatically
+// base64-encoded by the JSON marshaling of []byte.
+type secret struct {
+	Metadata objectMeta        `json:"metadata"`
+	Data     map[string][]byte `json:"data,omitempty"`
+	Type     string            `json:"type,omitempty"`
+}
+
+type secretList struct {
+	Items []secret `json:"items"`
+}
+
+// lease is a Kubernetes coordination.k8s.io/v1 Lease, used for locking.
+type lease struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     leaseSpec  `json:"spec"`
+}
+
+type leaseSpec struct {
+	HolderIdentity *string `json:"holderIdentity,omitempty"`
+	AcquireTime    string  `json:"acquireTime,omitempty"`
+}
+
+func secretsPath(namespace string) string {
+	return fmt.Sprintf("/api/v1/namespaces/%s/secrets", namespace)
+}
+
+func secretPath(namespace, name string) string {
+	return fmt.Sprintf("/api/v1/namespaces/%s/secrets/%s", namespace, name)
+}
+
+func leasesPath(namespace string) string {
+	return fmt.Sprintf("/apis/coordination.k8s.io/v1/namespaces/%s/leases", namespace)
+}
+
+func leasePath(namespace, name string) string {
+	return fmt.Sprintf("/apis/coordination.k8s.io/v1/namespaces/%s/leases/%s", namespace, name)
+}
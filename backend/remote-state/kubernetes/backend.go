@@ -0,0 +1,257 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Paths used for in-cluster configuration when Terraform is running inside
+// a pod with a service account mounted.
+const (
+	inClusterTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	inClusterCAPath    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+// Secret names must be valid DNS-1123 subdomains, and we also embed the
+// workspace name and a chunk ordinal, so restrict the suffix accordingly.
+var validSecretSuffix = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// New creates a new backend for Kubernetes remote state.
+func New() backend.Backend {
+	s := &schema.Backend{
+		Schema: map[string]*schema.Schema{
+			"secret_suffix": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Suffix used when creating secrets. Secrets are named in the format: tfstate-{workspace}-{secret_suffix}",
+			},
+			"namespace": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_NAMESPACE", "default"),
+				Description: "Namespace to store the secret and lease in",
+			},
+			"labels": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Map of additional labels to apply to the secrets",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"host": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_HOST", ""),
+				Description: "The hostname (in form of URI) of the Kubernetes API server",
+			},
+			"token": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_TOKEN", ""),
+				Description: "Service account token used to authenticate to the API server",
+			},
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_USER", ""),
+				Description: "The username for HTTP basic authentication to the API server",
+			},
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_PASSWORD", ""),
+				Description: "The password for HTTP basic authentication to the API server",
+			},
+			"cluster_ca_certificate": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_CLUSTER_CA_CERT_DATA", ""),
+				Description: "PEM-encoded root certificates bundle for TLS authentication",
+			},
+			"insecure": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_INSECURE", false),
+				Description: "Whether the API server should be accessed without verifying the TLS certificate",
+			},
+			"config_path": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBECONFIG", ""),
+				Description: "Path to a kubeconfig file",
+			},
+			"config_context": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_CTX", ""),
+				Description: "Context to use from the kubeconfig file. Defaults to the current context",
+			},
+			"in_cluster_config": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_IN_CLUSTER_CONFIG", false),
+				Description: "Use service account and cluster CA mounted into the pod to configure the client",
+			},
+		},
+	}
+
+	b := &Backend{Backend: s}
+	b.Backend.ConfigureFunc = b.configure
+	return b
+}
+
+type Backend struct {
+	*schema.Backend
+
+	client       *apiClient
+	namespace    string
+	secretSuffix string
+	labels       map[string]string
+}
+
+func (b *Backend) configure(ctx context.Context) error {
+	data := schema.FromContextBackendConfig(ctx)
+
+	b.secretSuffix = data.Get("secret_suffix").(string)
+	if !validSecretSuffix.MatchString(b.secretSuffix) {
+		return fmt.Errorf("secret_suffix must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character")
+	}
+
+	b.namespace = data.Get("namespace").(string)
+
+	b.labels = map[string]string{}
+	if v, ok := data.GetOk("labels"); ok {
+		for k, val := range v.(map[string]interface{}) {
+			b.labels[k] = val.(string)
+		}
+	}
+
+	client, err := newAPIClient(data)
+	if err != nil {
+		return err
+	}
+	b.client = client
+
+	return nil
+}
+
+// newAPIClient builds the API client from the backend configuration,
+// preferring explicit connection settings, then in-cluster configuration,
+// then a kubeconfig file.
+func newAPIClient(data *schema.ResourceData) (*apiClient, error) {
+	host := data.Get("host").(string)
+	token := data.Get("token").(string)
+	username := data.Get("username").(string)
+	password := data.Get("password").(string)
+	caCert := []byte(data.Get("cluster_ca_certificate").(string))
+	insecure := data.Get("insecure").(bool)
+
+	useInCluster := data.Get("in_cluster_config").(bool)
+	if host == "" && !useInCluster {
+		// if no connection settings are given at all, fall back to in-cluster
+		// configuration automatically when it is available
+		if _, err := os.Stat(inClusterTokenPath); err == nil && os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+			useInCluster = true
+		}
+	}
+
+	switch {
+	case useInCluster:
+		serviceHost := os.Getenv("KUBERNETES_SERVICE_HOST")
+		servicePort := os.Getenv("KUBERNETES_SERVICE_PORT")
+		if serviceHost == "" || servicePort == "" {
+			return nil, fmt.Errorf("in_cluster_config is set but the KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT environment variables are not; this does not appear to be running inside a cluster")
+		}
+		host = fmt.Sprintf("https://%s:%s", serviceHost, servicePort)
+
+		tokenData, err := ioutil.ReadFile(inClusterTokenPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read in-cluster service account token: %s", err)
+		}
+		token = string(tokenData)
+
+		if len(caCert) == 0 {
+			caCert, err = ioutil.ReadFile(inClusterCAPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read in-cluster CA certificate: %s", err)
+			}
+		}
+
+	case host != "":
+		// explicit connection settings; nothing more to resolve
+
+	default:
+		// fall back to a kubeconfig file
+		configPath := data.Get("config_path").(string)
+		if configPath == "" {
+			configPath = filepathDefaultKubeconfig()
+		}
+		kc, err := loadKubeconfig(configPath, data.Get("config_context").(string))
+		if err != nil {
+			return nil, err
+		}
+
+		host = kc.Host
+		if token == "" {
+			token = kc.Token
+		}
+		if username == "" {
+			username = kc.Username
+			password = kc.Password
+		}
+		if len(caCert) == 0 {
+			caCert = kc.CACert
+		}
+		if kc.Insecure {
+			insecure = true
+		}
+	}
+
+	baseURL, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Kubernetes API host %q: %s", host, err)
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, fmt.Errorf("Kubernetes API host must be an HTTP or HTTPS URL, got %q", host)
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: insecure,
+	}
+	if len(caCert) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse cluster_ca_certificate as PEM certificate data")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &apiClient{
+		BaseURL: baseURL,
+		HTTPClient: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		BearerToken: token,
+		Username:    username,
+		Password:    password,
+	}, nil
+}
+
+func filepathDefaultKubeconfig() string {
+	home := os.Getenv("HOME")
+	if home == "" {
+		home = os.Getenv("USERPROFILE") // windows
+	}
+	return home + "/.kube/config"
+}
@@ -0,0 +1,114 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/state/remote"
+	"github.com/hashicorp/terraform/states"
+)
+
+func (b *Backend) Workspaces() ([]string, error) {
+	query := url.Values{}
+	query.Set("labelSelector", fmt.Sprintf("%s=true,%s=%s",
+		managedLabel, secretSuffixLabel, b.secretSuffix))
+
+	list := &secretList{}
+	if err := b.client.do("GET", secretsPath(b.namespace), query, nil, list); err != nil {
+		return nil, err
+	}
+
+	// each workspace has one secret per chunk, so de-duplicate by the
+	// workspace label
+	seen := map[string]bool{}
+	var workspaces []string
+	for _, sec := range list.Items {
+		name := sec.Metadata.Labels[workspaceLabel]
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		workspaces = append(workspaces, name)
+	}
+
+	sort.Strings(workspaces)
+	return workspaces, nil
+}
+
+func (b *Backend) DeleteWorkspace(name string) error {
+	if name == backend.DefaultStateName || name == "" {
+		return fmt.Errorf("can't delete default state")
+	}
+
+	return b.remoteClient(name).Delete()
+}
+
+func (b *Backend) StateMgr(name string) (state.State, error) {
+	c := b.remoteClient(name)
+	var stateMgr state.State = &remote.State{Client: c}
+
+	// Check to see if this state already exists. If it doesn't, we have to
+	// assume this is a normal create operation, and take the lock so we can
+	// write an empty state as a sentinel value so Workspaces() knows the
+	// workspace exists.
+	existing, err := b.Workspaces()
+	if err != nil {
+		return nil, err
+	}
+
+	exists := false
+	for _, s := range existing {
+		if s == name {
+			exists = true
+			break
+		}
+	}
+
+	if !exists {
+		lockInfo := state.NewLockInfo()
+		lockInfo.Operation = "init"
+		lockID, err := stateMgr.Lock(lockInfo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock Kubernetes state: %s", err)
+		}
+
+		// Local helper function so we can call it multiple places
+		lockUnlock := func(parent error) error {
+			if err := stateMgr.Unlock(lockID); err != nil {
+				return fmt.Errorf("error unlocking Kubernetes state: %s", err)
+			}
+			return parent
+		}
+
+		if v := stateMgr.State(); v == nil {
+			if err := stateMgr.WriteState(states.NewState()); err != nil {
+				err = lockUnlock(err)
+				return nil, err
+			}
+			if err := stateMgr.PersistState(); err != nil {
+				err = lockUnlock(err)
+				return nil, err
+			}
+		}
+
+		// Unlock, the state should now be initialized
+		if err := lockUnlock(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return stateMgr, nil
+}
+
+func (b *Backend) remoteClient(name string) *RemoteClient {
+	return &RemoteClient{
+		Client:       b.client,
+		Namespace:    b.namespace,
+		SecretSuffix: b.secretSuffix,
+		Workspace:    name,
+		Labels:       b.labels,
+	}
+}
@@ -0,0 +1,268 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
+)
+
+func TestBackend_impl(t *testing.T) {
+	var _ backend.Backend = new(Backend)
+}
+
+func TestBackendConfig(t *testing.T) {
+	srv := newFakeAPIServer(t)
+	defer srv.Close()
+
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"secret_suffix": "unit-test",
+		"host":          srv.URL,
+		"namespace":     "test-ns",
+	})).(*Backend)
+
+	if b.secretSuffix != "unit-test" {
+		t.Fatalf("wrong secret_suffix %q", b.secretSuffix)
+	}
+	if b.namespace != "test-ns" {
+		t.Fatalf("wrong namespace %q", b.namespace)
+	}
+	if b.client == nil {
+		t.Fatal("client not configured")
+	}
+}
+
+func TestBackendSecretSuffixValidation(t *testing.T) {
+	valid := []string{"state", "my-state", "s0me-1"}
+	invalid := []string{"", "-state", "state-", "My.State", "state_1"}
+
+	for _, s := range valid {
+		if !validSecretSuffix.MatchString(s) {
+			t.Errorf("%q should be a valid secret_suffix", s)
+		}
+	}
+	for _, s := range invalid {
+		if validSecretSuffix.MatchString(s) {
+			t.Errorf("%q should not be a valid secret_suffix", s)
+		}
+	}
+}
+
+func TestBackendStates(t *testing.T) {
+	srv := newFakeAPIServer(t)
+	defer srv.Close()
+
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"secret_suffix": "unit-test",
+		"host":          srv.URL,
+	}))
+
+	backendtest.TestBackendStates(t, b)
+}
+
+func TestBackendLocks(t *testing.T) {
+	srv := newFakeAPIServer(t)
+	defer srv.Close()
+
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"secret_suffix": "unit-test",
+		"host":          srv.URL,
+	}))
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"secret_suffix": "unit-test",
+		"host":          srv.URL,
+	}))
+
+	backendtest.TestBackendStateLocks(t, b1, b2)
+}
+
+// fakeAPIServer emulates the few Kubernetes API endpoints this backend
+// uses, storing objects in memory.
+type fakeAPIServer struct {
+	mu sync.Mutex
+	// objects maps a collection path (e.g. /api/v1/namespaces/ns/secrets)
+	// to the objects within it by name.
+	objects map[string]map[string]*fakeObject
+	rv      int
+}
+
+type fakeObject struct {
+	meta objectMeta
+	raw  []byte
+}
+
+func newFakeAPIServer(t *testing.T) *httptest.Server {
+	f := &fakeAPIServer{
+		objects: map[string]map[string]*fakeObject{},
+	}
+	return httptest.NewServer(f)
+}
+
+func (f *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// paths are .../namespaces/{ns}/{kind} for collections, with /{name}
+	// appended for a single object
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	var collection, name string
+	parts := strings.Split(path, "/")
+	switch parts[len(parts)-1] {
+	case "secrets", "leases":
+		collection = path
+	default:
+		collection = strings.Join(parts[:len(parts)-1], "/")
+		name = parts[len(parts)-1]
+	}
+
+	objs := f.objects[collection]
+	if objs == nil {
+		objs = map[string]*fakeObject{}
+		f.objects[collection] = objs
+	}
+
+	switch {
+	case name == "" && r.Method == "GET":
+		f.list(w, r, objs)
+	case name == "" && r.Method == "POST":
+		f.create(w, r, objs)
+	case r.Method == "GET":
+		f.get(w, objs, name)
+	case r.Method == "PUT":
+		f.update(w, r, objs, name)
+	case r.Method == "DELETE":
+		f.delete(w, objs, name)
+	default:
+		f.status(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (f *fakeAPIServer) status(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(apiStatus{Message: message, Code: code})
+}
+
+func (f *fakeAPIServer) decode(r *http.Request) (*fakeObject, error) {
+	var envelope struct {
+		Metadata objectMeta `json:"metadata"`
+	}
+	var raw json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, err
+	}
+	return &fakeObject{meta: envelope.Metadata, raw: raw}, nil
+}
+
+// setResourceVersion records a new resourceVersion on the object, rewriting
+// the raw JSON so that later GETs return the new version.
+func (o *fakeObject) setResourceVersion(rv string) {
+	o.meta.ResourceVersion = rv
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(o.raw, &decoded); err != nil {
+		return
+	}
+	meta, _ := decoded["metadata"].(map[string]interface{})
+	if meta == nil {
+		meta = map[string]interface{}{}
+		decoded["metadata"] = meta
+	}
+	meta["resourceVersion"] = rv
+	if raw, err := json.Marshal(decoded); err == nil {
+		o.raw = raw
+	}
+}
+
+func (f *fakeAPIServer) list(w http.ResponseWriter, r *http.Request, objs map[string]*fakeObject) {
+	selector := map[string]string{}
+	if sel := r.URL.Query().Get("labelSelector"); sel != "" {
+		for _, req := range strings.Split(sel, ",") {
+			kv := strings.SplitN(req, "=", 2)
+			if len(kv) == 2 {
+				selector[kv[0]] = kv[1]
+			}
+		}
+	}
+
+	items := []json.RawMessage{}
+Objects:
+	for _, obj := range objs {
+		for k, v := range selector {
+			if obj.meta.Labels[k] != v {
+				continue Objects
+			}
+		}
+		items = append(items, obj.raw)
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+}
+
+func (f *fakeAPIServer) create(w http.ResponseWriter, r *http.Request, objs map[string]*fakeObject) {
+	obj, err := f.decode(r)
+	if err != nil {
+		f.status(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, exists := objs[obj.meta.Name]; exists {
+		f.status(w, http.StatusConflict, fmt.Sprintf("%q already exists", obj.meta.Name))
+		return
+	}
+
+	f.rv++
+	obj.setResourceVersion(fmt.Sprintf("%d", f.rv))
+	objs[obj.meta.Name] = obj
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(obj.raw)
+}
+
+func (f *fakeAPIServer) get(w http.ResponseWriter, objs map[string]*fakeObject, name string) {
+	obj, exists := objs[name]
+	if !exists {
+		f.status(w, http.StatusNotFound, fmt.Sprintf("%q not found", name))
+		return
+	}
+	w.Write(obj.raw)
+}
+
+func (f *fakeAPIServer) update(w http.ResponseWriter, r *http.Request, objs map[string]*fakeObject, name string) {
+	existing, exists := objs[name]
+	if !exists {
+		f.status(w, http.StatusNotFound, fmt.Sprintf("%q not found", name))
+		return
+	}
+
+	obj, err := f.decode(r)
+	if err != nil {
+		f.status(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if obj.meta.ResourceVersion != existing.meta.ResourceVersion {
+		f.status(w, http.StatusConflict, fmt.Sprintf("%q was modified; resourceVersion does not match", name))
+		return
+	}
+
+	f.rv++
+	obj.setResourceVersion(fmt.Sprintf("%d", f.rv))
+	objs[name] = obj
+	w.Write(obj.raw)
+}
+
+func (f *fakeAPIServer) delete(w http.ResponseWriter, objs map[string]*fakeObject, name string) {
+	if _, exists := objs[name]; !exists {
+		f.status(w, http.StatusNotFound, fmt.Sprintf("%q not found", name))
+		return
+	}
+	delete(objs, name)
+	f.status(w, http.StatusOK, "deleted")
+}
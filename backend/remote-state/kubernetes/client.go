@@ -0,0 +1,294 @@
+package kubernetes
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/state/remote"
+)
+
+const (
+	// stateSecretDataKey is the key under which each chunk of state data is
+	// stored in its Secret.
+	stateSecretDataKey = "tfstate"
+
+	// Secret data values count against the etcd object size limit (1MiB by
+	// default) after base64 encoding, so chunk the raw state conservatively.
+	// This is a variable so that tests can exercise chunking with small
+	// payloads.
+	defaultChunkSize = 512 * 1024
+
+	// annotations on the first chunk's Secret describing the whole state
+	chunkCountAnnotation = "app.terraform.io/tfstate-chunks"
+	stateMD5Annotation   = "app.terraform.io/tfstate-md5"
+
+	// annotation on the lock Lease holding the serialized LockInfo
+	lockInfoAnnotation = "app.terraform.io/lock-info"
+
+	// labels applied to every object this backend creates
+	managedLabel      = "tfstate"
+	workspaceLabel    = "tfstate-workspace"
+	secretSuffixLabel = "tfstate-secret-suffix"
+)
+
+// RemoteClient stores a workspace's state in one or more Kubernetes Secrets
+// and locks it with a coordination.k8s.io Lease.
+type RemoteClient struct {
+	Client       *apiClient
+	Namespace    string
+	SecretSuffix string
+	Workspace    string
+	Labels       map[string]string
+
+	// ChunkSize is the maximum number of raw state bytes stored per Secret;
+	// zero means defaultChunkSize.
+	ChunkSize int
+}
+
+var (
+	_ remote.Client       = (*RemoteClient)(nil)
+	_ remote.ClientLocker = (*RemoteClient)(nil)
+)
+
+func (c *RemoteClient) Get() (*remote.Payload, error) {
+	primary, err := c.getSecret(c.secretName(0))
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	data := primary.Data[stateSecretDataKey]
+
+	chunks := 1
+	if v := primary.Metadata.Annotations[chunkCountAnnotation]; v != "" {
+		chunks, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("state secret %s has invalid %s annotation %q", c.secretName(0), chunkCountAnnotation, v)
+		}
+	}
+
+	for i := 1; i < chunks; i++ {
+		chunk, err := c.getSecret(c.secretName(i))
+		if err != nil {
+			if isNotFound(err) {
+				return nil, fmt.Errorf("state secret %s is missing; the stored state is incomplete", c.secretName(i))
+			}
+			return nil, err
+		}
+		data = append(data, chunk.Data[stateSecretDataKey]...)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	hash := md5.Sum(data)
+	return &remote.Payload{
+		Data: data,
+		MD5:  hash[:],
+	}, nil
+}
+
+func (c *RemoteClient) Put(data []byte) error {
+	hash := md5.Sum(data)
+	chunks := splitChunks(data, c.chunkSize())
+
+	for i, chunk := range chunks {
+		sec := &secret{
+			Metadata: objectMeta{
+				Name:      c.secretName(i),
+				Namespace: c.Namespace,
+				Labels:    c.objectLabels(),
+			},
+			Data: map[string][]byte{
+				stateSecretDataKey: chunk,
+			},
+			Type: "Opaque",
+		}
+		if i == 0 {
+			sec.Metadata.Annotations = map[string]string{
+				chunkCountAnnotation: strconv.Itoa(len(chunks)),
+				stateMD5Annotation:   fmt.Sprintf("%x", hash),
+			}
+		}
+
+		if err := c.upsertSecret(sec); err != nil {
+			return fmt.Errorf("failed to store state in secret %s: %s", sec.Metadata.Name, err)
+		}
+	}
+
+	// remove any chunks left over from a previous, larger state
+	return c.deleteSecrets(len(chunks))
+}
+
+func (c *RemoteClient) Delete() error {
+	return c.deleteSecrets(0)
+}
+
+func (c *RemoteClient) Lock(info *state.LockInfo) (string, error) {
+	holder := info.ID
+	leaseObj := &lease{
+		Metadata: objectMeta{
+			Name:      c.leaseName(),
+			Namespace: c.Namespace,
+			Labels:    c.objectLabels(),
+			Annotations: map[string]string{
+				lockInfoAnnotation: string(info.Marshal()),
+			},
+		},
+		Spec: leaseSpec{
+			HolderIdentity: &holder,
+		},
+	}
+
+	err := c.Client.do("POST", leasesPath(c.Namespace), nil, leaseObj, nil)
+	if err == nil {
+		return info.ID, nil
+	}
+	if !isConflict(err) {
+		return "", err
+	}
+
+	// The lease already exists, so the state is locked by someone else;
+	// report who currently holds it.
+	lockErr := &state.LockError{Err: fmt.Errorf("the state is already locked by another terraform client")}
+	existing := &lease{}
+	if getErr := c.Client.do("GET", leasePath(c.Namespace, c.leaseName()), nil, nil, existing); getErr == nil {
+		if raw := existing.Metadata.Annotations[lockInfoAnnotation]; raw != "" {
+			existingInfo := &state.LockInfo{}
+			if jsonErr := json.Unmarshal([]byte(raw), existingInfo); jsonErr == nil {
+				lockErr.Info = existingInfo
+			}
+		}
+	}
+	return "", lockErr
+}
+
+func (c *RemoteClient) Unlock(id string) error {
+	existing := &lease{}
+	err := c.Client.do("GET", leasePath(c.Namespace, c.leaseName()), nil, nil, existing)
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("state is not locked")
+		}
+		return err
+	}
+
+	if existing.Spec.HolderIdentity == nil || *existing.Spec.HolderIdentity != id {
+		lockErr := &state.LockError{Err: fmt.Errorf("lock id %q does not match existing lock", id)}
+		if raw := existing.Metadata.Annotations[lockInfoAnnotation]; raw != "" {
+			existingInfo := &state.LockInfo{}
+			if jsonErr := json.Unmarshal([]byte(raw), existingInfo); jsonErr == nil {
+				lockErr.Info = existingInfo
+			}
+		}
+		return lockErr
+	}
+
+	return c.Client.do("DELETE", leasePath(c.Namespace, c.leaseName()), nil, nil, nil)
+}
+
+// secretName returns the name of the Secret holding the given chunk of this
+// workspace's state. The first chunk has no ordinal suffix so that states
+// small enough to fit in one Secret use the documented naming scheme.
+func (c *RemoteClient) secretName(chunk int) string {
+	name := fmt.Sprintf("tfstate-%s-%s", c.Workspace, c.SecretSuffix)
+	if chunk > 0 {
+		name = fmt.Sprintf("%s-chunk%d", name, chunk)
+	}
+	return name
+}
+
+func (c *RemoteClient) leaseName() string {
+	return fmt.Sprintf("lock-tfstate-%s-%s", c.Workspace, c.SecretSuffix)
+}
+
+func (c *RemoteClient) chunkSize() int {
+	if c.ChunkSize > 0 {
+		return c.ChunkSize
+	}
+	return defaultChunkSize
+}
+
+func (c *RemoteClient) objectLabels() map[string]string {
+	labels := map[string]string{
+		managedLabel:      "true",
+		workspaceLabel:    c.Workspace,
+		secretSuffixLabel: c.SecretSuffix,
+	}
+	for k, v := range c.Labels {
+		labels[k] = v
+	}
+	return labels
+}
+
+func (c *RemoteClient) getSecret(name string) (*secret, error) {
+	sec := &secret{}
+	if err := c.Client.do("GET", secretPath(c.Namespace, name), nil, nil, sec); err != nil {
+		return nil, err
+	}
+	return sec, nil
+}
+
+// upsertSecret creates the given Secret, or replaces it if it already
+// exists.
+func (c *RemoteClient) upsertSecret(sec *secret) error {
+	err := c.Client.do("POST", secretsPath(c.Namespace), nil, sec, nil)
+	if err == nil || !isConflict(err) {
+		return err
+	}
+
+	// already exists; replace it, preserving the resourceVersion required
+	// for an update
+	existing, err := c.getSecret(sec.Metadata.Name)
+	if err != nil {
+		return err
+	}
+	sec.Metadata.ResourceVersion = existing.Metadata.ResourceVersion
+	return c.Client.do("PUT", secretPath(c.Namespace, sec.Metadata.Name), nil, sec, nil)
+}
+
+// deleteSecrets removes all state Secrets for this workspace with a chunk
+// ordinal of keep or higher. Passing zero removes the state entirely.
+func (c *RemoteClient) deleteSecrets(keep int) error {
+	query := url.Values{}
+	query.Set("labelSelector", fmt.Sprintf("%s=true,%s=%s,%s=%s",
+		managedLabel, workspaceLabel, c.Workspace, secretSuffixLabel, c.SecretSuffix))
+
+	list := &secretList{}
+	if err := c.Client.do("GET", secretsPath(c.Namespace), query, nil, list); err != nil {
+		return err
+	}
+
+	keepNames := map[string]bool{}
+	for i := 0; i < keep; i++ {
+		keepNames[c.secretName(i)] = true
+	}
+
+	for _, sec := range list.Items {
+		if keepNames[sec.Metadata.Name] {
+			continue
+		}
+		if err := c.Client.do("DELETE", secretPath(c.Namespace, sec.Metadata.Name), nil, nil, nil); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitChunks divides data into chunks of at most size bytes, always
+// returning at least one (possibly empty) chunk.
+func splitChunks(data []byte, size int) [][]byte {
+	var chunks [][]byte
+	for len(data) > size {
+		chunks = append(chunks, data[:size])
+		data = data[size:]
+	}
+	return append(chunks, data)
+}
@@ -0,0 +1,141 @@
+package kubernetes
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
+	"github.com/hashicorp/terraform/state/remote"
+)
+
+func TestRemoteClient_impl(t *testing.T) {
+	var _ remote.Client = new(RemoteClient)
+	var _ remote.ClientLocker = new(RemoteClient)
+}
+
+func TestRemoteClient(t *testing.T) {
+	srv := newFakeAPIServer(t)
+	defer srv.Close()
+
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"secret_suffix": "unit-test",
+		"host":          srv.URL,
+	})).(*Backend)
+
+	remote.TestClient(t, b.remoteClient(backend.DefaultStateName))
+}
+
+func TestRemoteClientLocks(t *testing.T) {
+	srv := newFakeAPIServer(t)
+	defer srv.Close()
+
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"secret_suffix": "unit-test",
+		"host":          srv.URL,
+	})).(*Backend)
+
+	remote.TestRemoteLocks(t,
+		b.remoteClient(backend.DefaultStateName),
+		b.remoteClient(backend.DefaultStateName),
+	)
+}
+
+func TestRemoteClientChunkedState(t *testing.T) {
+	srv := newFakeAPIServer(t)
+	defer srv.Close()
+
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"secret_suffix": "unit-test",
+		"host":          srv.URL,
+	})).(*Backend)
+
+	client := b.remoteClient(backend.DefaultStateName)
+	client.ChunkSize = 16 // force chunking with a small payload
+
+	data := []byte("0123456789abcdefghijklmnopqrstuvwxyz") // 36 bytes: 3 chunks
+	if err := client.Put(data); err != nil {
+		t.Fatal(err)
+	}
+
+	// the chunk secrets must exist individually
+	for i, want := range []string{"0123456789abcdef", "ghijklmnopqrstuv", "wxyz"} {
+		sec, err := client.getSecret(client.secretName(i))
+		if err != nil {
+			t.Fatalf("missing chunk %d: %s", i, err)
+		}
+		if got := string(sec.Data[stateSecretDataKey]); got != want {
+			t.Fatalf("wrong data in chunk %d: got %q, want %q", i, got, want)
+		}
+	}
+
+	// and Get must reassemble them
+	payload, err := client.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(payload.Data, data) {
+		t.Fatalf("wrong data after round-trip: got %q, want %q", payload.Data, data)
+	}
+
+	// writing a smaller state must remove the now-unused chunks
+	small := []byte("small")
+	if err := client.Put(small); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.getSecret(client.secretName(1)); !isNotFound(err) {
+		t.Fatalf("expected stale chunk 1 to be deleted, got %v", err)
+	}
+	payload, err = client.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(payload.Data, small) {
+		t.Fatalf("wrong data after rewrite: got %q, want %q", payload.Data, small)
+	}
+
+	if err := client.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	payload, err = client.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload != nil {
+		t.Fatalf("expected nil payload after delete, got %#v", payload)
+	}
+}
+
+func TestSplitChunks(t *testing.T) {
+	tests := []struct {
+		data  string
+		size  int
+		want  int
+		first string
+	}{
+		{"", 4, 1, ""},
+		{"abc", 4, 1, "abc"},
+		{"abcd", 4, 1, "abcd"},
+		{"abcde", 4, 2, "abcd"},
+		{"abcdefgh", 4, 2, "abcd"},
+		{"abcdefghi", 4, 3, "abcd"},
+	}
+
+	for _, test := range tests {
+		chunks := splitChunks([]byte(test.data), test.size)
+		if len(chunks) != test.want {
+			t.Errorf("splitChunks(%q, %d) produced %d chunks; want %d", test.data, test.size, len(chunks), test.want)
+			continue
+		}
+		if string(chunks[0]) != test.first {
+			t.Errorf("splitChunks(%q, %d) first chunk is %q; want %q", test.data, test.size, chunks[0], test.first)
+		}
+		var joined []byte
+		for _, c := range chunks {
+			joined = append(joined, c...)
+		}
+		if string(joined) != test.data {
+			t.Errorf("splitChunks(%q, %d) chunks don't rejoin to the original data", test.data, test.size)
+		}
+	}
+}
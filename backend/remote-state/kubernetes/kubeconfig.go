@@ -0,0 +1,134 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// kubeconfigSettings holds the connection settings resolved from a
+// kubeconfig file for a single context.
+type kubeconfigSettings struct {
+	Host     string
+	CACert   []byte
+	Insecure bool
+	Token    string
+	Username string
+	Password string
+}
+
+// The structures below describe only the parts of the kubeconfig file
+// format that we use.
+type kubeconfigFile struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token     string `yaml:"token"`
+			TokenFile string `yaml:"tokenFile"`
+			Username  string `yaml:"username"`
+			Password  string `yaml:"password"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// loadKubeconfig reads connection settings for the given context (or the
+// file's current context when contextName is empty) from the kubeconfig
+// file at the given path.
+func loadKubeconfig(path, contextName string) (*kubeconfigSettings, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig at %s: %s", path, err)
+	}
+
+	var kc kubeconfigFile
+	if err := yaml.Unmarshal(raw, &kc); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig at %s: %s", path, err)
+	}
+
+	if contextName == "" {
+		contextName = kc.CurrentContext
+	}
+	if contextName == "" {
+		return nil, fmt.Errorf("kubeconfig at %s has no current context; set config_context in the backend configuration", path)
+	}
+
+	var clusterName, userName string
+	for _, c := range kc.Contexts {
+		if c.Name == contextName {
+			clusterName = c.Context.Cluster
+			userName = c.Context.User
+			break
+		}
+	}
+	if clusterName == "" {
+		return nil, fmt.Errorf("context %q not found in kubeconfig at %s", contextName, path)
+	}
+
+	settings := &kubeconfigSettings{}
+
+	found := false
+	for _, c := range kc.Clusters {
+		if c.Name != clusterName {
+			continue
+		}
+		found = true
+		settings.Host = c.Cluster.Server
+		settings.Insecure = c.Cluster.InsecureSkipTLSVerify
+		switch {
+		case c.Cluster.CertificateAuthorityData != "":
+			ca, err := base64.StdEncoding.DecodeString(c.Cluster.CertificateAuthorityData)
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate-authority-data for cluster %q: %s", clusterName, err)
+			}
+			settings.CACert = ca
+		case c.Cluster.CertificateAuthority != "":
+			ca, err := ioutil.ReadFile(c.Cluster.CertificateAuthority)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read certificate-authority for cluster %q: %s", clusterName, err)
+			}
+			settings.CACert = ca
+		}
+		break
+	}
+	if !found {
+		return nil, fmt.Errorf("cluster %q not found in kubeconfig at %s", clusterName, path)
+	}
+
+	for _, u := range kc.Users {
+		if u.Name != userName {
+			continue
+		}
+		settings.Token = u.User.Token
+		if settings.Token == "" && u.User.TokenFile != "" {
+			token, err := ioutil.ReadFile(u.User.TokenFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read tokenFile for user %q: %s", userName, err)
+			}
+			settings.Token = string(token)
+		}
+		settings.Username = u.User.Username
+		settings.Password = u.User.Password
+		break
+	}
+
+	return settings, nil
+}
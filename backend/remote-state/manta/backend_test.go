@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/joyent/triton-go/storage"
 )
 
@@ -38,7 +39,7 @@ func TestBackend(t *testing.T) {
 	directory := fmt.Sprintf("terraform-remote-manta-test-%x", time.Now().Unix())
 	keyName := "testState"
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"path":        directory,
 		"object_name": keyName,
 	})).(*Backend)
@@ -46,7 +47,7 @@ func TestBackend(t *testing.T) {
 	createMantaFolder(t, b.storageClient, directory)
 	defer deleteMantaFolder(t, b.storageClient, directory)
 
-	backend.TestBackendStates(t, b)
+	backendtest.TestBackendStates(t, b)
 }
 
 func TestBackendLocked(t *testing.T) {
@@ -55,12 +56,12 @@ func TestBackendLocked(t *testing.T) {
 	directory := fmt.Sprintf("terraform-remote-manta-test-%x", time.Now().Unix())
 	keyName := "testState"
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"path":        directory,
 		"object_name": keyName,
 	})).(*Backend)
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"path":        directory,
 		"object_name": keyName,
 	})).(*Backend)
@@ -68,8 +69,8 @@ func TestBackendLocked(t *testing.T) {
 	createMantaFolder(t, b1.storageClient, directory)
 	defer deleteMantaFolder(t, b1.storageClient, directory)
 
-	backend.TestBackendStateLocks(t, b1, b2)
-	backend.TestBackendStateForceUnlock(t, b1, b2)
+	backendtest.TestBackendStateLocks(t, b1, b2)
+	backendtest.TestBackendStateForceUnlock(t, b1, b2)
 }
 
 func createMantaFolder(t *testing.T, mantaClient *storage.StorageClient, directoryName string) {
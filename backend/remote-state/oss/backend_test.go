@@ -11,6 +11,7 @@ import (
 	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/aliyun/aliyun-tablestore-go-sdk/tablestore"
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/configs/hcl2shim"
 )
 
@@ -44,7 +45,7 @@ func TestBackendConfig(t *testing.T) {
 		"tablestore_table":    "TableStore",
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(config)).(*Backend)
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(config)).(*Backend)
 
 	if !strings.HasPrefix(b.ossClient.Config.Endpoint, "https://oss-cn-beijing") {
 		t.Fatalf("Incorrect region was provided")
@@ -79,7 +80,7 @@ func TestBackendConfigProfile(t *testing.T) {
 		"profile":             "default",
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(config)).(*Backend)
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(config)).(*Backend)
 
 	if !strings.HasPrefix(b.ossClient.Config.Endpoint, "https://oss-cn-beijing") {
 		t.Fatalf("Incorrect region was provided")
@@ -125,12 +126,12 @@ func TestBackend(t *testing.T) {
 	bucketName := fmt.Sprintf("terraform-remote-oss-test-%x", time.Now().Unix())
 	statePrefix := "multi/level/path/"
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"bucket": bucketName,
 		"prefix": statePrefix,
 	})).(*Backend)
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"bucket": bucketName,
 		"prefix": statePrefix,
 	})).(*Backend)
@@ -138,9 +139,9 @@ func TestBackend(t *testing.T) {
 	createOSSBucket(t, b1.ossClient, bucketName)
 	defer deleteOSSBucket(t, b1.ossClient, bucketName)
 
-	backend.TestBackendStates(t, b1)
-	backend.TestBackendStateLocks(t, b1, b2)
-	backend.TestBackendStateForceUnlock(t, b1, b2)
+	backendtest.TestBackendStates(t, b1)
+	backendtest.TestBackendStateLocks(t, b1, b2)
+	backendtest.TestBackendStateForceUnlock(t, b1, b2)
 }
 
 func createOSSBucket(t *testing.T, ossClient *oss.Client, bucketName string) {
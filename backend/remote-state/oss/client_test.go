@@ -10,6 +10,7 @@ import (
 	"crypto/md5"
 
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/state"
 	"github.com/hashicorp/terraform/state/remote"
 	"github.com/hashicorp/terraform/states/statefile"
@@ -28,7 +29,7 @@ func TestRemoteClient(t *testing.T) {
 	bucketName := fmt.Sprintf("tf-remote-oss-test-%x", time.Now().Unix())
 	path := "testState"
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"bucket":  bucketName,
 		"prefix":  path,
 		"encrypt": true,
@@ -51,7 +52,7 @@ func TestRemoteClientLocks(t *testing.T) {
 	tableName := fmt.Sprintf("tfRemoteTestForce%x", time.Now().Unix())
 	path := "testState"
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"encrypt":             true,
@@ -59,7 +60,7 @@ func TestRemoteClientLocks(t *testing.T) {
 		"tablestore_endpoint": RemoteTestUsedOTSEndpoint,
 	})).(*Backend)
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"encrypt":             true,
@@ -92,7 +93,7 @@ func TestRemoteClientLocks_multipleStates(t *testing.T) {
 	tableName := fmt.Sprintf("tfRemoteTestForce%x", time.Now().Unix())
 	path := "testState"
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"encrypt":             true,
@@ -100,7 +101,7 @@ func TestRemoteClientLocks_multipleStates(t *testing.T) {
 		"tablestore_endpoint": RemoteTestUsedOTSEndpoint,
 	})).(*Backend)
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"encrypt":             true,
@@ -138,7 +139,7 @@ func TestRemoteForceUnlock(t *testing.T) {
 	tableName := fmt.Sprintf("tfRemoteTestForce%x", time.Now().Unix())
 	path := "testState"
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"encrypt":             true,
@@ -146,7 +147,7 @@ func TestRemoteForceUnlock(t *testing.T) {
 		"tablestore_endpoint": RemoteTestUsedOTSEndpoint,
 	})).(*Backend)
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"encrypt":             true,
@@ -218,7 +219,7 @@ func TestRemoteClient_clientMD5(t *testing.T) {
 	tableName := fmt.Sprintf("tfRemoteTestForce%x", time.Now().Unix())
 	path := "testState"
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"tablestore_table":    tableName,
@@ -268,7 +269,7 @@ func TestRemoteClient_stateChecksum(t *testing.T) {
 	tableName := fmt.Sprintf("tfRemoteTestForce%x", time.Now().Unix())
 	path := "testState"
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"tablestore_table":    tableName,
@@ -301,7 +302,7 @@ func TestRemoteClient_stateChecksum(t *testing.T) {
 
 	// Use b2 without a tablestore_table to bypass the lock table to write the state directly.
 	// client2 will write the "incorrect" state, simulating oss eventually consistency delays
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"bucket": bucketName,
 		"prefix": path,
 	})).(*Backend)
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/state/remote"
 	_ "github.com/lib/pq"
 )
@@ -43,11 +44,11 @@ func TestBackendConfig(t *testing.T) {
 	}
 	defer dbCleaner.Query(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schemaName))
 
-	config := backend.TestWrapConfig(map[string]interface{}{
+	config := backendtest.TestWrapConfig(map[string]interface{}{
 		"conn_str":    connStr,
 		"schema_name": schemaName,
 	})
-	b := backend.TestBackendConfig(t, New(), config).(*Backend)
+	b := backendtest.TestBackendConfig(t, New(), config).(*Backend)
 
 	if b == nil {
 		t.Fatal("Backend could not be configured")
@@ -86,12 +87,12 @@ func TestBackendConfigSkipSchema(t *testing.T) {
 	db.Query(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", schemaName))
 	defer db.Query(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schemaName))
 
-	config := backend.TestWrapConfig(map[string]interface{}{
+	config := backendtest.TestWrapConfig(map[string]interface{}{
 		"conn_str":             connStr,
 		"schema_name":          schemaName,
 		"skip_schema_creation": true,
 	})
-	b := backend.TestBackendConfig(t, New(), config).(*Backend)
+	b := backendtest.TestBackendConfig(t, New(), config).(*Backend)
 
 	if b == nil {
 		t.Fatal("Backend could not be configured")
@@ -127,17 +128,17 @@ func TestBackendStates(t *testing.T) {
 	}
 	defer dbCleaner.Query(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schemaName))
 
-	config := backend.TestWrapConfig(map[string]interface{}{
+	config := backendtest.TestWrapConfig(map[string]interface{}{
 		"conn_str":    connStr,
 		"schema_name": schemaName,
 	})
-	b := backend.TestBackendConfig(t, New(), config).(*Backend)
+	b := backendtest.TestBackendConfig(t, New(), config).(*Backend)
 
 	if b == nil {
 		t.Fatal("Backend could not be configured")
 	}
 
-	backend.TestBackendStates(t, b)
+	backendtest.TestBackendStates(t, b)
 }
 
 func TestBackendStateLocks(t *testing.T) {
@@ -150,23 +151,23 @@ func TestBackendStateLocks(t *testing.T) {
 	}
 	defer dbCleaner.Query(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schemaName))
 
-	config := backend.TestWrapConfig(map[string]interface{}{
+	config := backendtest.TestWrapConfig(map[string]interface{}{
 		"conn_str":    connStr,
 		"schema_name": schemaName,
 	})
-	b := backend.TestBackendConfig(t, New(), config).(*Backend)
+	b := backendtest.TestBackendConfig(t, New(), config).(*Backend)
 
 	if b == nil {
 		t.Fatal("Backend could not be configured")
 	}
 
-	bb := backend.TestBackendConfig(t, New(), config).(*Backend)
+	bb := backendtest.TestBackendConfig(t, New(), config).(*Backend)
 
 	if bb == nil {
 		t.Fatal("Backend could not be configured")
 	}
 
-	backend.TestBackendStateLocks(t, b, bb)
+	backendtest.TestBackendStateLocks(t, b, bb)
 }
 
 func getDatabaseUrl() string {
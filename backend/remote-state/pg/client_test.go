@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/state/remote"
 )
 
@@ -27,11 +28,11 @@ func TestRemoteClient(t *testing.T) {
 	}
 	defer dbCleaner.Query(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schemaName))
 
-	config := backend.TestWrapConfig(map[string]interface{}{
+	config := backendtest.TestWrapConfig(map[string]interface{}{
 		"conn_str":    connStr,
 		"schema_name": schemaName,
 	})
-	b := backend.TestBackendConfig(t, New(), config).(*Backend)
+	b := backendtest.TestBackendConfig(t, New(), config).(*Backend)
 
 	if b == nil {
 		t.Fatal("Backend could not be configured")
@@ -55,18 +56,18 @@ func TestRemoteLocks(t *testing.T) {
 	}
 	defer dbCleaner.Query(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schemaName))
 
-	config := backend.TestWrapConfig(map[string]interface{}{
+	config := backendtest.TestWrapConfig(map[string]interface{}{
 		"conn_str":    connStr,
 		"schema_name": schemaName,
 	})
 
-	b1 := backend.TestBackendConfig(t, New(), config).(*Backend)
+	b1 := backendtest.TestBackendConfig(t, New(), config).(*Backend)
 	s1, err := b1.StateMgr(backend.DefaultStateName)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	b2 := backend.TestBackendConfig(t, New(), config).(*Backend)
+	b2 := backendtest.TestBackendConfig(t, New(), config).(*Backend)
 	s2, err := b2.StateMgr(backend.DefaultStateName)
 	if err != nil {
 		t.Fatal(err)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 )
 
 // verify that we are doing ACC tests or the Swift tests specifically
@@ -42,7 +43,7 @@ func TestBackendConfig(t *testing.T) {
 		"container":         container,
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(config)).(*Backend)
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(config)).(*Backend)
 
 	if b.container != container {
 		t.Fatal("Incorrect container was provided.")
@@ -57,11 +58,11 @@ func TestBackend(t *testing.T) {
 
 	container := fmt.Sprintf("terraform-state-swift-testbackend-%x", time.Now().Unix())
 
-	be0 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	be0 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"container": container,
 	})).(*Backend)
 
-	be1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	be1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"container": container,
 	})).(*Backend)
 
@@ -72,9 +73,9 @@ func TestBackend(t *testing.T) {
 
 	defer client.deleteContainer()
 
-	backend.TestBackendStates(t, be0)
-	backend.TestBackendStateLocks(t, be0, be1)
-	backend.TestBackendStateForceUnlock(t, be0, be1)
+	backendtest.TestBackendStates(t, be0)
+	backendtest.TestBackendStateLocks(t, be0, be1)
+	backendtest.TestBackendStateForceUnlock(t, be0, be1)
 }
 
 func TestBackendArchive(t *testing.T) {
@@ -83,12 +84,12 @@ func TestBackendArchive(t *testing.T) {
 	container := fmt.Sprintf("terraform-state-swift-testarchive-%x", time.Now().Unix())
 	archiveContainer := fmt.Sprintf("%s_archive", container)
 
-	be0 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	be0 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"archive_container": archiveContainer,
 		"container":         container,
 	})).(*Backend)
 
-	be1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	be1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
 		"archive_container": archiveContainer,
 		"container":         container,
 	})).(*Backend)
@@ -109,7 +110,7 @@ func TestBackendArchive(t *testing.T) {
 		aclient.deleteContainer()
 	}()
 
-	backend.TestBackendStates(t, be0)
-	backend.TestBackendStateLocks(t, be0, be1)
-	backend.TestBackendStateForceUnlock(t, be0, be1)
+	backendtest.TestBackendStates(t, be0)
+	backendtest.TestBackendStateLocks(t, be0, be1)
+	backendtest.TestBackendStateForceUnlock(t, be0, be1)
 }
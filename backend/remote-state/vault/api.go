@@ -0,0 +1,183 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// vaultClient is a minimal Vault HTTP API client covering only the KV v2
+// operations this backend needs, so we don't depend on the full Vault API
+// client library.
+type vaultClient struct {
+	// BaseURL is the Vault server address, e.g. https://vault.example.com:8200
+	BaseURL *url.URL
+
+	// HTTPClient is configured with any TLS settings from the backend
+	// configuration.
+	HTTPClient *http.Client
+
+	// Token is sent as X-Vault-Token on every request.
+	Token string
+
+	// Namespace, if non-empty, is sent as X-Vault-Namespace for Vault
+	// Enterprise namespaces.
+	Namespace string
+}
+
+// vaultError represents a non-2xx response from Vault.
+type vaultError struct {
+	StatusCode int
+	Errors     []string
+}
+
+func (e *vaultError) Error() string {
+	if len(e.Errors) > 0 {
+		return fmt.Sprintf("Vault error (HTTP %d): %s", e.StatusCode, strings.Join(e.Errors, "; "))
+	}
+	return fmt.Sprintf("Vault error (HTTP %d)", e.StatusCode)
+}
+
+// isNotFound returns true if the given error is a vaultError for a 404
+// response.
+func isNotFound(err error) bool {
+	vErr, ok := err.(*vaultError)
+	return ok && vErr.StatusCode == http.StatusNotFound
+}
+
+// isCASConflict returns true if the given error is Vault's rejection of a
+// KV v2 write whose check-and-set option did not match the current version.
+func isCASConflict(err error) bool {
+	vErr, ok := err.(*vaultError)
+	if !ok || vErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	for _, msg := range vErr.Errors {
+		if strings.Contains(msg, "check-and-set") {
+			return true
+		}
+	}
+	return false
+}
+
+// do performs a single API request against the given path under /v1/. A
+// non-nil body is sent as JSON, and a non-nil out receives the "data"
+// member of the response. Non-2xx responses are returned as a *vaultError.
+func (c *vaultClient) do(method, apiPath string, query url.Values, body, out interface{}) error {
+	reqURL := *c.BaseURL
+	reqURL.Path = path.Join(reqURL.Path, "v1", apiPath)
+	if query != nil {
+		reqURL.RawQuery = query.Encode()
+	}
+
+	var reqBody *bytes.Reader
+	if body != nil {
+		js, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(js)
+	} else {
+		reqBody = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, reqURL.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", c.Token)
+	if c.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		vErr := &vaultError{StatusCode: resp.StatusCode}
+		// the body has an "errors" list on a best-effort basis
+		var parsed struct {
+			Errors []string `json:"errors"`
+		}
+		json.Unmarshal(respBody, &parsed)
+		vErr.Errors = parsed.Errors
+		return vErr
+	}
+
+	if out != nil {
+		var envelope struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(respBody, &envelope); err != nil {
+			return fmt.Errorf("failed to decode Vault response: %s", err)
+		}
+		if err := json.Unmarshal(envelope.Data, out); err != nil {
+			return fmt.Errorf("failed to decode Vault response data: %s", err)
+		}
+	}
+	return nil
+}
+
+// kvRead reads the current version of the KV v2 secret at the given path
+// within the mount, returning its key/value data.
+func (c *vaultClient) kvRead(mount, secretPath string) (map[string]string, error) {
+	var out struct {
+		Data map[string]string `json:"data"`
+	}
+	err := c.do("GET", path.Join(mount, "data", secretPath), nil, nil, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Data, nil
+}
+
+// kvWrite writes a new version of the KV v2 secret at the given path. If
+// cas is non-negative it is sent as the check-and-set option, so the write
+// only succeeds if the secret's current version matches; zero means the
+// secret must not yet exist.
+func (c *vaultClient) kvWrite(mount, secretPath string, data map[string]string, cas int) error {
+	body := map[string]interface{}{
+		"data": data,
+	}
+	if cas >= 0 {
+		body["options"] = map[string]interface{}{"cas": cas}
+	}
+	return c.do("POST", path.Join(mount, "data", secretPath), nil, body, nil)
+}
+
+// kvDestroy permanently removes the KV v2 secret at the given path,
+// including all its versions and metadata.
+func (c *vaultClient) kvDestroy(mount, secretPath string) error {
+	return c.do("DELETE", path.Join(mount, "metadata", secretPath), nil, nil, nil)
+}
+
+// kvList returns the keys directly below the given path prefix. Keys that
+// name a deeper hierarchy have a trailing slash, matching the Vault API.
+func (c *vaultClient) kvList(mount, prefix string) ([]string, error) {
+	var out struct {
+		Keys []string `json:"keys"`
+	}
+	query := url.Values{}
+	query.Set("list", "true")
+	err := c.do("GET", path.Join(mount, "metadata", prefix), query, nil, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Keys, nil
+}
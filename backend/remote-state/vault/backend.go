@@ -0,0 +1,131 @@
+package vault
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// New creates a new backend for Vault KV v2 remote state.
+func New() backend.Backend {
+	s := &schema.Backend{
+		Schema: map[string]*schema.Schema{
+			"address": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VAULT_ADDR", ""),
+				Description: "The address of the Vault server",
+			},
+			"token": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VAULT_TOKEN", ""),
+				Description: "The Vault token used to authenticate",
+			},
+			"namespace": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VAULT_NAMESPACE", ""),
+				Description: "The Vault Enterprise namespace to use",
+			},
+			"mount": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "secret",
+				Description: "The mount path of the KV v2 secrets engine holding the state",
+			},
+			"path": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path within the mount under which state is stored, in the format: {path}/{workspace}/tfstate",
+			},
+			"ca_cert_file": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VAULT_CACERT", ""),
+				Description: "Path to a PEM-encoded CA certificate file used to verify the Vault server's TLS certificate",
+			},
+			"skip_tls_verify": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VAULT_SKIP_VERIFY", false),
+				Description: "Whether to skip verification of the Vault server's TLS certificate",
+			},
+		},
+	}
+
+	b := &Backend{Backend: s}
+	b.Backend.ConfigureFunc = b.configure
+	return b
+}
+
+type Backend struct {
+	*schema.Backend
+
+	client *vaultClient
+	mount  string
+	path   string
+}
+
+func (b *Backend) configure(ctx context.Context) error {
+	data := schema.FromContextBackendConfig(ctx)
+
+	address := data.Get("address").(string)
+	if address == "" {
+		return fmt.Errorf("address must be set, or the VAULT_ADDR environment variable provided")
+	}
+	baseURL, err := url.Parse(address)
+	if err != nil {
+		return fmt.Errorf("failed to parse Vault address %q: %s", address, err)
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return fmt.Errorf("Vault address must be an HTTP or HTTPS URL, got %q", address)
+	}
+
+	token := data.Get("token").(string)
+	if token == "" {
+		return fmt.Errorf("token must be set, or the VAULT_TOKEN environment variable provided")
+	}
+
+	b.mount = strings.Trim(data.Get("mount").(string), "/")
+	b.path = strings.Trim(data.Get("path").(string), "/")
+	if b.path == "" {
+		return fmt.Errorf("path must not be empty")
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: data.Get("skip_tls_verify").(bool),
+	}
+	if caFile := data.Get("ca_cert_file").(string); caFile != "" {
+		caCert, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return fmt.Errorf("failed to read ca_cert_file: %s", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return fmt.Errorf("failed to parse ca_cert_file as PEM certificate data")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	b.client = &vaultClient{
+		BaseURL: baseURL,
+		HTTPClient: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		Token:     token,
+		Namespace: data.Get("namespace").(string),
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package vault
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/state/remote"
+	"github.com/hashicorp/terraform/states"
+)
+
+func (b *Backend) Workspaces() ([]string, error) {
+	keys, err := b.client.kvList(b.mount, b.path)
+	if err != nil {
+		if isNotFound(err) {
+			// no state has been written yet
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// each workspace is a folder under the base path holding its tfstate
+	// and lock secrets
+	var workspaces []string
+	for _, key := range keys {
+		if !strings.HasSuffix(key, "/") {
+			continue
+		}
+		workspaces = append(workspaces, strings.TrimSuffix(key, "/"))
+	}
+
+	sort.Strings(workspaces)
+	return workspaces, nil
+}
+
+func (b *Backend) DeleteWorkspace(name string) error {
+	if name == backend.DefaultStateName || name == "" {
+		return fmt.Errorf("can't delete default state")
+	}
+
+	c := b.remoteClient(name)
+	if err := c.Delete(); err != nil {
+		return err
+	}
+
+	// also remove any leftover lock secret so the folder disappears from
+	// the listing entirely
+	if err := b.client.kvDestroy(b.mount, c.lockPath()); err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (b *Backend) StateMgr(name string) (state.State, error) {
+	c := b.remoteClient(name)
+	var stateMgr state.State = &remote.State{Client: c}
+
+	// Check to see if this state already exists. If it doesn't, we have to
+	// assume this is a normal create operation, and take the lock so we
+	// can write an empty state as a sentinel value so Workspaces() knows
+	// the workspace exists.
+	existing, err := b.Workspaces()
+	if err != nil {
+		return nil, err
+	}
+
+	exists := false
+	for _, s := range existing {
+		if s == name {
+			exists = true
+			break
+		}
+	}
+
+	if !exists {
+		lockInfo := state.NewLockInfo()
+		lockInfo.Operation = "init"
+		lockID, err := stateMgr.Lock(lockInfo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock Vault state: %s", err)
+		}
+
+		// Local helper function so we can call it multiple places
+		lockUnlock := func(parent error) error {
+			if err := stateMgr.Unlock(lockID); err != nil {
+				return fmt.Errorf("error unlocking Vault state: %s", err)
+			}
+			return parent
+		}
+
+		if v := stateMgr.State(); v == nil {
+			if err := stateMgr.WriteState(states.NewState()); err != nil {
+				err = lockUnlock(err)
+				return nil, err
+			}
+			if err := stateMgr.PersistState(); err != nil {
+				err = lockUnlock(err)
+				return nil, err
+			}
+		}
+
+		// Unlock, the state should now be initialized
+		if err := lockUnlock(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return stateMgr, nil
+}
+
+func (b *Backend) remoteClient(name string) *RemoteClient {
+	return &RemoteClient{
+		Client:    b.client,
+		Mount:     b.mount,
+		Path:      b.path,
+		Workspace: name,
+	}
+}
@@ -0,0 +1,197 @@
+package vault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
+)
+
+func TestBackend_impl(t *testing.T) {
+	var _ backend.Backend = new(Backend)
+}
+
+func TestBackendConfig(t *testing.T) {
+	srv := newFakeVaultServer(t)
+	defer srv.Close()
+
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"address": srv.URL,
+		"token":   "test-token",
+		"mount":   "kv",
+		"path":    "terraform/state",
+	})).(*Backend)
+
+	if b.mount != "kv" {
+		t.Fatalf("wrong mount %q", b.mount)
+	}
+	if b.path != "terraform/state" {
+		t.Fatalf("wrong path %q", b.path)
+	}
+	if b.client == nil {
+		t.Fatal("client not configured")
+	}
+	if b.client.Token != "test-token" {
+		t.Fatalf("wrong token %q", b.client.Token)
+	}
+}
+
+func TestBackendStates(t *testing.T) {
+	srv := newFakeVaultServer(t)
+	defer srv.Close()
+
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"address": srv.URL,
+		"token":   "test-token",
+		"path":    "terraform/state",
+	}))
+
+	backendtest.TestBackendStates(t, b)
+}
+
+func TestBackendLocks(t *testing.T) {
+	srv := newFakeVaultServer(t)
+	defer srv.Close()
+
+	b1 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"address": srv.URL,
+		"token":   "test-token",
+		"path":    "terraform/state",
+	}))
+	b2 := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"address": srv.URL,
+		"token":   "test-token",
+		"path":    "terraform/state",
+	}))
+
+	backendtest.TestBackendStateLocks(t, b1, b2)
+}
+
+// fakeVaultServer emulates the KV v2 endpoints this backend uses, storing
+// secret versions in memory.
+type fakeVaultServer struct {
+	mu sync.Mutex
+	// secrets maps mount-relative secret paths to their version history;
+	// the last element is the current version.
+	secrets map[string][]map[string]string
+}
+
+func newFakeVaultServer(t *testing.T) *httptest.Server {
+	f := &fakeVaultServer{
+		secrets: map[string][]map[string]string{},
+	}
+	return httptest.NewServer(f)
+}
+
+func (f *fakeVaultServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// paths are /v1/{mount}/data/{path} or /v1/{mount}/metadata/{path}
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/v1/"), "/", 3)
+	if len(parts) != 3 {
+		f.errors(w, http.StatusNotFound, "unsupported path")
+		return
+	}
+	kind, secretPath := parts[1], parts[2]
+
+	switch {
+	case kind == "data" && r.Method == "GET":
+		f.read(w, secretPath)
+	case kind == "data" && r.Method == "POST":
+		f.write(w, r, secretPath)
+	case kind == "metadata" && r.Method == "GET" && r.URL.Query().Get("list") == "true":
+		f.list(w, secretPath)
+	case kind == "metadata" && r.Method == "DELETE":
+		f.destroy(w, secretPath)
+	default:
+		f.errors(w, http.StatusMethodNotAllowed, "unsupported operation")
+	}
+}
+
+func (f *fakeVaultServer) errors(w http.ResponseWriter, code int, messages ...string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{"errors": messages})
+}
+
+func (f *fakeVaultServer) read(w http.ResponseWriter, secretPath string) {
+	versions := f.secrets[secretPath]
+	if len(versions) == 0 {
+		f.errors(w, http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{
+			"data":     versions[len(versions)-1],
+			"metadata": map[string]interface{}{"version": len(versions)},
+		},
+	})
+}
+
+func (f *fakeVaultServer) write(w http.ResponseWriter, r *http.Request, secretPath string) {
+	var body struct {
+		Data    map[string]string `json:"data"`
+		Options struct {
+			CAS *int `json:"cas"`
+		} `json:"options"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		f.errors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	current := len(f.secrets[secretPath])
+	if body.Options.CAS != nil && *body.Options.CAS != current {
+		f.errors(w, http.StatusBadRequest, "check-and-set parameter did not match the current version")
+		return
+	}
+
+	f.secrets[secretPath] = append(f.secrets[secretPath], body.Data)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{"version": current + 1},
+	})
+}
+
+func (f *fakeVaultServer) destroy(w http.ResponseWriter, secretPath string) {
+	if _, exists := f.secrets[secretPath]; !exists {
+		f.errors(w, http.StatusNotFound)
+		return
+	}
+	delete(f.secrets, secretPath)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeVaultServer) list(w http.ResponseWriter, prefix string) {
+	seen := map[string]bool{}
+	var keys []string
+	for secretPath := range f.secrets {
+		if !strings.HasPrefix(secretPath, prefix+"/") {
+			continue
+		}
+		rest := strings.TrimPrefix(secretPath, prefix+"/")
+		key := rest
+		if i := strings.Index(rest, "/"); i >= 0 {
+			key = rest[:i+1] // deeper hierarchy: report the folder
+		}
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		f.errors(w, http.StatusNotFound)
+		return
+	}
+
+	sort.Strings(keys)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{"keys": keys},
+	})
+}
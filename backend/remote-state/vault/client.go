@@ -0,0 +1,148 @@
+package vault
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/state"
+	"github.com/hashicorp/terraform/state/remote"
+)
+
+const (
+	// keys within the state secret's data
+	stateDataKey = "tfstate"
+	stateMD5Key  = "md5"
+
+	// key within the lock secret's data holding the serialized LockInfo
+	lockInfoKey = "info"
+)
+
+// RemoteClient stores a workspace's state as a KV v2 secret, so every
+// write becomes a new secret version and the data is encrypted at rest by
+// Vault. A sentinel secret written with check-and-set version 0 acts as
+// the lock: the write can only succeed for one client while the secret
+// does not exist.
+type RemoteClient struct {
+	Client    *vaultClient
+	Mount     string
+	Path      string
+	Workspace string
+}
+
+var (
+	_ remote.Client       = (*RemoteClient)(nil)
+	_ remote.ClientLocker = (*RemoteClient)(nil)
+)
+
+func (c *RemoteClient) Get() (*remote.Payload, error) {
+	secretData, err := c.Client.kvRead(c.Mount, c.statePath())
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	encoded := secretData[stateDataKey]
+	if encoded == "" {
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("state stored at %s/%s is not valid base64: %s", c.Mount, c.statePath(), err)
+	}
+
+	hash := md5.Sum(data)
+	return &remote.Payload{
+		Data: data,
+		MD5:  hash[:],
+	}, nil
+}
+
+func (c *RemoteClient) Put(data []byte) error {
+	hash := md5.Sum(data)
+	err := c.Client.kvWrite(c.Mount, c.statePath(), map[string]string{
+		stateDataKey: base64.StdEncoding.EncodeToString(data),
+		stateMD5Key:  fmt.Sprintf("%x", hash),
+	}, -1)
+	if err != nil {
+		return fmt.Errorf("failed to store state in Vault: %s", err)
+	}
+	return nil
+}
+
+func (c *RemoteClient) Delete() error {
+	err := c.Client.kvDestroy(c.Mount, c.statePath())
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *RemoteClient) Lock(info *state.LockInfo) (string, error) {
+	// Writing with cas: 0 succeeds only while the lock secret does not
+	// exist, so exactly one client can hold the lock.
+	err := c.Client.kvWrite(c.Mount, c.lockPath(), map[string]string{
+		lockInfoKey: string(info.Marshal()),
+	}, 0)
+	if err == nil {
+		return info.ID, nil
+	}
+	if !isCASConflict(err) {
+		return "", err
+	}
+
+	// The lock secret already exists, so the state is locked by someone
+	// else; report who currently holds it.
+	lockErr := &state.LockError{Err: fmt.Errorf("the state is already locked by another terraform client")}
+	if existing, infoErr := c.lockInfo(); infoErr == nil {
+		lockErr.Info = existing
+	}
+	return "", lockErr
+}
+
+func (c *RemoteClient) Unlock(id string) error {
+	existing, err := c.lockInfo()
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("state is not locked")
+		}
+		return err
+	}
+
+	if existing.ID != id {
+		return &state.LockError{
+			Err:  fmt.Errorf("lock id %q does not match existing lock", id),
+			Info: existing,
+		}
+	}
+
+	// Destroying the metadata removes all versions of the lock secret, so
+	// a subsequent cas: 0 write can succeed.
+	return c.Client.kvDestroy(c.Mount, c.lockPath())
+}
+
+// lockInfo reads and parses the current holder's lock info from the lock
+// secret.
+func (c *RemoteClient) lockInfo() (*state.LockInfo, error) {
+	secretData, err := c.Client.kvRead(c.Mount, c.lockPath())
+	if err != nil {
+		return nil, err
+	}
+
+	info := &state.LockInfo{}
+	if err := json.Unmarshal([]byte(secretData[lockInfoKey]), info); err != nil {
+		return nil, fmt.Errorf("state is locked, but the lock secret at %s/%s could not be parsed: %s", c.Mount, c.lockPath(), err)
+	}
+	return info, nil
+}
+
+func (c *RemoteClient) statePath() string {
+	return fmt.Sprintf("%s/%s/tfstate", c.Path, c.Workspace)
+}
+
+func (c *RemoteClient) lockPath() string {
+	return fmt.Sprintf("%s/%s/lock", c.Path, c.Workspace)
+}
@@ -0,0 +1,78 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
+	"github.com/hashicorp/terraform/state/remote"
+)
+
+func TestRemoteClient_impl(t *testing.T) {
+	var _ remote.Client = new(RemoteClient)
+	var _ remote.ClientLocker = new(RemoteClient)
+}
+
+func TestRemoteClient(t *testing.T) {
+	srv := newFakeVaultServer(t)
+	defer srv.Close()
+
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"address": srv.URL,
+		"token":   "test-token",
+		"path":    "terraform/state",
+	})).(*Backend)
+
+	remote.TestClient(t, b.remoteClient(backend.DefaultStateName))
+}
+
+func TestRemoteClientLocks(t *testing.T) {
+	srv := newFakeVaultServer(t)
+	defer srv.Close()
+
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"address": srv.URL,
+		"token":   "test-token",
+		"path":    "terraform/state",
+	})).(*Backend)
+
+	remote.TestRemoteLocks(t,
+		b.remoteClient(backend.DefaultStateName),
+		b.remoteClient(backend.DefaultStateName),
+	)
+}
+
+// every state write must become a new secret version, so earlier states
+// remain recoverable through Vault's version history
+func TestRemoteClientVersions(t *testing.T) {
+	srv := newFakeVaultServer(t)
+	defer srv.Close()
+
+	b := backendtest.TestBackendConfig(t, New(), backendtest.TestWrapConfig(map[string]interface{}{
+		"address": srv.URL,
+		"token":   "test-token",
+		"path":    "terraform/state",
+	})).(*Backend)
+
+	client := b.remoteClient(backend.DefaultStateName)
+	if err := client.Put([]byte(`{"serial": 1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Put([]byte(`{"serial": 2}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := srv.Config.Handler.(*fakeVaultServer)
+	versions := fake.secrets["terraform/state/default/tfstate"]
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 secret versions, got %d", len(versions))
+	}
+
+	payload, err := client.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(payload.Data) != `{"serial": 2}` {
+		t.Fatalf("wrong current state %q", payload.Data)
+	}
+}
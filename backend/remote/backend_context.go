@@ -23,9 +23,12 @@ import (
 func (b *Remote) Context(op *backend.Operation) (*terraform.Context, statemgr.Full, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 
-	if op.LockState {
+	switch {
+	case op.LockState && op.StateLockQueue:
+		op.StateLocker = clistate.NewQueueLocker(context.Background(), op.StateLockTimeout, b.CLI, b.cliColorize())
+	case op.LockState:
 		op.StateLocker = clistate.NewLocker(context.Background(), op.StateLockTimeout, b.CLI, b.cliColorize())
-	} else {
+	default:
 		op.StateLocker = clistate.NewNoopLocker()
 	}
 
@@ -125,7 +128,7 @@ func (b *Remote) Context(op *backend.Operation) (*terraform.Context, statemgr.Fu
 		}
 
 		if op.Variables != nil {
-			variables, varDiags := backend.ParseVariableValues(op.Variables, config.Module.Variables)
+			variables, varDiags := backend.ParseVariableValues(op.Variables, config.Module.Variables, op.StrictVariables)
 			diags = diags.Append(varDiags)
 			if diags.HasErrors() {
 				return nil, nil, diags
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-svchost/disco"
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/version"
 	"github.com/zclconf/go-cty/cty"
 
@@ -22,16 +23,16 @@ func TestRemote_backendDefault(t *testing.T) {
 	b, bCleanup := testBackendDefault(t)
 	defer bCleanup()
 
-	backend.TestBackendStates(t, b)
-	backend.TestBackendStateLocks(t, b, b)
-	backend.TestBackendStateForceUnlock(t, b, b)
+	backendtest.TestBackendStates(t, b)
+	backendtest.TestBackendStateLocks(t, b, b)
+	backendtest.TestBackendStateForceUnlock(t, b, b)
 }
 
 func TestRemote_backendNoDefault(t *testing.T) {
 	b, bCleanup := testBackendNoDefault(t)
 	defer bCleanup()
 
-	backend.TestBackendStates(t, b)
+	backendtest.TestBackendStates(t, b)
 }
 
 func TestRemote_config(t *testing.T) {
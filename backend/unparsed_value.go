@@ -41,7 +41,12 @@ type UnparsedVariableValue interface {
 // InputValues may be incomplete but will include the subset of variables
 // that were successfully processed, allowing for careful analysis of the
 // partial result.
-func ParseVariableValues(vv map[string]UnparsedVariableValue, decls map[string]*configs.Variable) (terraform.InputValues, tfdiags.Diagnostics) {
+//
+// If strict is set then a value for an undeclared variable is always an
+// error, regardless of where the value came from. This is intended for
+// automation, where a mistyped variable name would otherwise be silently
+// ignored or reported only as a warning.
+func ParseVariableValues(vv map[string]UnparsedVariableValue, decls map[string]*configs.Variable, strict bool) (terraform.InputValues, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 	ret := make(terraform.InputValues, len(vv))
 
@@ -69,6 +74,14 @@ func ParseVariableValues(vv map[string]UnparsedVariableValue, decls map[string]*
 		if !declared {
 			switch val.SourceType {
 			case terraform.ValueFromConfig, terraform.ValueFromAutoFile, terraform.ValueFromNamedFile:
+				if strict {
+					diags = diags.Append(tfdiags.Sourceless(
+						tfdiags.Error,
+						"Value for undeclared variable",
+						fmt.Sprintf("The root module does not declare a variable named %q but a value was found in file %q. To use this value, add a \"variable\" block to the configuration.", name, val.SourceRange.Filename),
+					))
+					continue
+				}
 				// These source types have source ranges, so we can produce
 				// a nice error message with good context.
 				//
@@ -93,6 +106,13 @@ func ParseVariableValues(vv map[string]UnparsedVariableValue, decls map[string]*
 				// variables, because users will often set these globally
 				// when they are used across many (but not necessarily all)
 				// configurations.
+				if strict {
+					diags = diags.Append(tfdiags.Sourceless(
+						tfdiags.Error,
+						"Value for undeclared variable",
+						fmt.Sprintf("A variable named %q was set using the TF_VAR_%s environment variable, but the root module does not declare a variable of that name. To use this value, add a \"variable\" block to the configuration.", name, name),
+					))
+				}
 			case terraform.ValueFromCLIArg:
 				diags = diags.Append(tfdiags.Sourceless(
 					tfdiags.Error,
@@ -55,7 +55,7 @@ func TestParseVariableValuesUndeclared(t *testing.T) {
 		},
 	}
 
-	gotVals, diags := ParseVariableValues(vv, decls)
+	gotVals, diags := ParseVariableValues(vv, decls, false)
 	for _, diag := range diags {
 		t.Logf("%s: %s", diag.Description().Summary, diag.Description().Detail)
 	}
@@ -117,6 +117,39 @@ func TestParseVariableValuesUndeclared(t *testing.T) {
 	}
 }
 
+func TestParseVariableValuesUndeclaredStrict(t *testing.T) {
+	vv := map[string]UnparsedVariableValue{
+		"undeclared0": testUnparsedVariableValue("0"),
+		"declared1":   testUnparsedVariableValue("1"),
+	}
+	decls := map[string]*configs.Variable{
+		"declared1": {
+			Name:        "declared1",
+			Type:        cty.String,
+			ParsingMode: configs.VariableParseLiteral,
+			DeclRange: hcl.Range{
+				Filename: "fake.tf",
+				Start:    hcl.Pos{Line: 2, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 2, Column: 1, Byte: 0},
+			},
+		},
+	}
+
+	_, diags := ParseVariableValues(vv, decls, true)
+	if !diags.HasErrors() {
+		t.Fatalf("succeeded; want error for undeclared variable")
+	}
+	if got, want := len(diags), 1; got != want {
+		t.Fatalf("wrong number of diagnostics %d; want %d", got, want)
+	}
+	if got, want := diags[0].Severity(), tfdiags.Error; got != want {
+		t.Errorf("wrong severity %#v; want %#v", got, want)
+	}
+	if got, want := diags[0].Description().Summary, "Value for undeclared variable"; got != want {
+		t.Errorf("wrong summary\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
 type testUnparsedVariableValue string
 
 func (v testUnparsedVariableValue) ParseVariableValue(mode configs.VariableParsingMode) (*terraform.InputValue, tfdiags.Diagnostics) {
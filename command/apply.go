@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform/addrs"
 	"github.com/hashicorp/terraform/backend"
 	"github.com/hashicorp/terraform/configs/hcl2shim"
+	"github.com/hashicorp/terraform/plans/planfile"
 	"github.com/hashicorp/terraform/repl"
 	"github.com/hashicorp/terraform/states"
 	"github.com/hashicorp/terraform/tfdiags"
@@ -27,7 +28,9 @@ type ApplyCommand struct {
 }
 
 func (c *ApplyCommand) Run(args []string) int {
-	var destroyForce, refresh, autoApprove bool
+	var destroyForce, refresh, autoApprove, timing, strictVars bool
+	var allowStalePlan, forceThreshold bool
+	var profileDir, auditLog string
 	args = c.Meta.process(args)
 	cmdName := "apply"
 	if c.Destroy {
@@ -46,6 +49,17 @@ func (c *ApplyCommand) Run(args []string) int {
 	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
 	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
 	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
+	cmdFlags.BoolVar(&c.Meta.stateLockQueue, "lock-wait-queue", false, "wait in line for the state lock")
+	cmdFlags.BoolVar(&timing, "timing", false, "show operation timings")
+	cmdFlags.StringVar(&auditLog, "audit-log", os.Getenv("TF_AUDIT_LOG"), "append an audit record per resource operation to this file or URL")
+	cmdFlags.BoolVar(&strictVars, "strict-vars", false, "error on undeclared variable values")
+	cmdFlags.BoolVar(&forceThreshold, "force-threshold", false, "override max_destroy and max_changes limits")
+	if !c.Destroy {
+		cmdFlags.BoolVar(&allowStalePlan, "allow-stale-plan", false, "apply a saved plan even if the configuration has changed since it was created")
+	}
+	// -profile-dir is intentionally undocumented; it exists for gathering
+	// diagnostic data to attach to performance issue reports.
+	cmdFlags.StringVar(&profileDir, "profile-dir", "", "write pprof profiles to this directory")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
@@ -119,6 +133,16 @@ func (c *ApplyCommand) Run(args []string) int {
 			c.showDiagnostics(diags)
 			return 1
 		}
+
+		// Check that the configuration in the working directory still
+		// matches what the plan was created from, so we don't silently
+		// apply a stale plan.
+		moreDiags := c.verifyPlanProvenance(planFile, allowStalePlan)
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
 	}
 
 	// Load the backend
@@ -176,8 +200,13 @@ func (c *ApplyCommand) Run(args []string) int {
 	opReq.ConfigDir = configPath
 	opReq.Destroy = c.Destroy
 	opReq.DestroyForce = destroyForce
+	opReq.ForceThreshold = forceThreshold
 	opReq.PlanFile = planFile
 	opReq.PlanRefresh = refresh
+	opReq.ShowTimings = timing
+	opReq.ProfileDir = profileDir
+	opReq.AuditLogPath = auditLog
+	opReq.StrictVariables = strictVars
 	opReq.Type = backend.OperationTypeApply
 
 	opReq.ConfigLoader, err = c.initConfigLoader()
@@ -214,6 +243,89 @@ func (c *ApplyCommand) Run(args []string) int {
 	return op.Result.ExitStatus()
 }
 
+// verifyPlanProvenance checks the configuration hash recorded in the given
+// plan file's metadata against a hash of the configuration currently in the
+// working directory, and returns an error diagnostic if they don't match.
+//
+// Plan files created by earlier versions of Terraform carry no metadata,
+// and in some situations the working directory's configuration can't be
+// loaded at all; in both cases the check is skipped, since it is a
+// safety net rather than a guarantee.
+func (c *ApplyCommand) verifyPlanProvenance(planFile *planfile.Reader, allowStalePlan bool) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	meta, err := planFile.ReadMetadata()
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to read plan metadata",
+			fmt.Sprintf("Cannot read the provenance metadata from the given plan file: %s.", err),
+		))
+		return diags
+	}
+	if meta == nil || meta.ConfigHash == "" {
+		// Plan file predates metadata; nothing to verify against.
+		return diags
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Unable to verify plan provenance",
+			fmt.Sprintf("Cannot determine the current working directory to compare its configuration against the saved plan: %s.", err),
+		))
+		return diags
+	}
+
+	loader, err := c.initConfigLoader()
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Unable to verify plan provenance",
+			fmt.Sprintf("Cannot load the current configuration to compare it against the saved plan: %s.", err),
+		))
+		return diags
+	}
+
+	_, snap, loadDiags := loader.LoadConfigWithSnapshot(cwd)
+	if loadDiags.HasErrors() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Unable to verify plan provenance",
+			"Cannot load the current configuration to compare it against the saved plan, so Terraform cannot detect whether the plan is stale.",
+		))
+		return diags
+	}
+	if root, exists := snap.Modules[""]; !exists || len(root.Files) == 0 {
+		// The working directory contains no configuration at all, which is
+		// legitimate when applying a saved plan (the plan embeds its own
+		// config snapshot), so there is nothing to compare against.
+		return diags
+	}
+
+	if currentHash := planfile.ConfigSnapshotHash(snap); currentHash != meta.ConfigHash {
+		if allowStalePlan {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Warning,
+				"Applying a stale plan",
+				"The configuration in the working directory has changed since this plan was created, but -allow-stale-plan was set so Terraform will apply it anyway.",
+			))
+			return diags
+		}
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Saved plan is stale",
+			fmt.Sprintf(
+				"The configuration in the working directory has changed since this plan was created at %s, so applying it may not produce the planned result.\n\nRun \"terraform plan\" to create a new plan from the current configuration, or set -allow-stale-plan to apply this plan anyway.",
+				meta.CreatedAt.Format("2006-01-02 15:04:05 MST"),
+			),
+		))
+	}
+
+	return diags
+}
+
 func (c *ApplyCommand) Help() string {
 	if c.Destroy {
 		return c.helpDestroy()
@@ -244,6 +356,21 @@ Usage: terraform apply [options] [DIR-OR-PLAN]
 
 Options:
 
+  -allow-stale-plan      Apply a saved plan file even if the configuration in
+                         the working directory has changed since the plan was
+                         created.
+
+  -ascii                 Restrict output to ASCII characters, for terminals
+                         and CI log consoles that can't render anything else.
+                         May also be set via the TF_UI_ASCII environment
+                         variable.
+
+  -audit-log=path        Append one JSONL audit record per resource operation
+                         to the given file, recording the action, object
+                         checksums, duration, and outcome. An http or https
+                         URL ships the records to that endpoint instead.
+                         Defaults to the TF_AUDIT_LOG environment variable.
+
   -auto-approve          Skip interactive approval of plan before applying.
 
   -backup=path           Path to backup the existing state file before
@@ -254,10 +381,18 @@ Options:
                          accompanied by errors, show them in a more compact
                          form that includes only the summary messages.
 
+  -force-threshold       Proceed even if the plan exceeds a max_destroy or
+                         max_changes limit set in the configuration.
+
   -lock=true             Lock the state file when locking is supported.
 
   -lock-timeout=0s       Duration to retry a state lock.
 
+  -lock-wait-queue       Instead of failing when the state is already locked,
+                         wait in line for the lock to be released, periodically
+                         reporting who currently holds it. With no
+                         -lock-timeout the wait is unbounded.
+
   -input=true            Ask for input for variables if not directly set.
 
   -no-color              If specified, output won't contain any color.
@@ -275,10 +410,24 @@ Options:
                          "-state". This can be used to preserve the old
                          state.
 
+  -strict-vars           Treat values provided for undeclared variables (via
+                         -var, -var-file, or TF_VAR_ environment variables) as
+                         errors, and never prompt for missing required
+                         variables.
+
   -target=resource       Resource to target. Operation will be limited to this
                          resource and its dependencies. This flag can be used
                          multiple times.
 
+  -timing                Show a report of per-resource provider operation
+                         durations after the apply completes, listing the
+                         slowest resources and providers.
+
+  -ui-theme=name         Select the color theme for output: "dark" (the
+                         default), "light" for light terminal backgrounds, or
+                         "none" to disable color like -no-color. May also be
+                         set via the TF_UI_THEME environment variable.
+
   -var 'foo=bar'         Set a variable in the Terraform configuration. This
                          flag can be set multiple times.
 
@@ -303,14 +452,33 @@ Options:
                          modifying. Defaults to the "-state-out" path with
                          ".backup" extension. Set to "-" to disable backup.
 
+  -ascii                 Restrict output to ASCII characters, for terminals
+                         and CI log consoles that can't render anything else.
+                         May also be set via the TF_UI_ASCII environment
+                         variable.
+
+  -audit-log=path        Append one JSONL audit record per resource operation
+                         to the given file, recording the action, object
+                         checksums, duration, and outcome. An http or https
+                         URL ships the records to that endpoint instead.
+                         Defaults to the TF_AUDIT_LOG environment variable.
+
   -auto-approve          Skip interactive approval before destroying.
 
   -force                 Deprecated: same as auto-approve.
 
+  -force-threshold       Proceed even if the plan exceeds a max_destroy or
+                         max_changes limit set in the configuration.
+
   -lock=true             Lock the state file when locking is supported.
 
   -lock-timeout=0s       Duration to retry a state lock.
 
+  -lock-wait-queue       Instead of failing when the state is already locked,
+                         wait in line for the lock to be released, periodically
+                         reporting who currently holds it. With no
+                         -lock-timeout the wait is unbounded.
+
   -no-color              If specified, output won't contain any color.
 
   -parallelism=n         Limit the number of concurrent operations.
@@ -326,10 +494,24 @@ Options:
                          "-state". This can be used to preserve the old
                          state.
 
+  -strict-vars           Treat values provided for undeclared variables (via
+                         -var, -var-file, or TF_VAR_ environment variables) as
+                         errors, and never prompt for missing required
+                         variables.
+
   -target=resource       Resource to target. Operation will be limited to this
                          resource and its dependencies. This flag can be used
                          multiple times.
 
+  -timing                Show a report of per-resource provider operation
+                         durations after the apply completes, listing the
+                         slowest resources and providers.
+
+  -ui-theme=name         Select the color theme for output: "dark" (the
+                         default), "light" for light terminal backgrounds, or
+                         "none" to disable color like -no-color. May also be
+                         set via the TF_UI_THEME environment variable.
+
   -var 'foo=bar'         Set a variable in the Terraform configuration. This
                          flag can be set multiple times.
 
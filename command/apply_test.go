@@ -21,6 +21,7 @@ import (
 
 	"github.com/hashicorp/terraform/addrs"
 	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/helper/copy"
 	"github.com/hashicorp/terraform/plans"
 	"github.com/hashicorp/terraform/providers"
 	"github.com/hashicorp/terraform/state"
@@ -583,6 +584,65 @@ func TestApply_plan(t *testing.T) {
 	}
 }
 
+func TestApply_planStale(t *testing.T) {
+	td := tempDir(t)
+	copy.CopyDir(testFixturePath("apply"), td)
+	defer os.RemoveAll(td)
+	defer testChdir(t, td)()
+
+	planPath := applyFixturePlanFile(t)
+	statePath := testTempFile(t)
+
+	// Change the working directory's configuration so it no longer matches
+	// what the plan was created from.
+	mainTf := filepath.Join(td, "main.tf")
+	src, err := ioutil.ReadFile(mainTf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src = append(src, []byte("\nresource \"test_instance\" \"extra\" {\n    ami = \"baz\"\n}\n")...)
+	if err := ioutil.WriteFile(mainTf, src, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := applyFixtureProvider()
+	ui := new(cli.MockUi)
+	c := &ApplyCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{
+		"-state-out", statePath,
+		planPath,
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("expected failure for stale plan, got %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "Saved plan is stale") {
+		t.Fatalf("unexpected error output:\n%s", ui.ErrorWriter.String())
+	}
+
+	// With the override the apply must proceed anyway.
+	ui = new(cli.MockUi)
+	c = &ApplyCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+		},
+	}
+	args = []string{
+		"-state-out", statePath,
+		"-allow-stale-plan",
+		planPath,
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+}
+
 func TestApply_plan_backup(t *testing.T) {
 	planPath := applyFixturePlanFile(t)
 	statePath := testTempFile(t)
@@ -30,6 +30,9 @@ by multiple users at the same time. Please resolve the issue above and try
 again. For most commands, you can disable locking with the "-lock=false"
 flag, but this is not recommended.`
 
+	LockQueueMessage = `The state is currently locked by %s (operation %q, held for %s).
+Waiting in line for the lock to be released...`
+
 	UnlockMessage      = "Releasing state lock. This may take a few moments..."
 	UnlockErrorMessage = `
 [reset][bold][red]Error releasing the state lock![reset][red]
@@ -73,6 +76,7 @@ type locker struct {
 	mu      sync.Mutex
 	ctx     context.Context
 	timeout time.Duration
+	queue   bool
 	state   statemgr.Locker
 	ui      cli.Ui
 	color   *colorstring.Colorize
@@ -98,6 +102,27 @@ func NewLocker(
 	return l
 }
 
+// NewQueueLocker is a variant of NewLocker for when the user has asked to
+// queue for a held lock rather than fail. While waiting it reports who is
+// currently holding the lock and for how long, and a zero timeout means to
+// wait until the lock frees (or the context is canceled) rather than to
+// give up immediately.
+func NewQueueLocker(
+	ctx context.Context,
+	timeout time.Duration,
+	ui cli.Ui,
+	color *colorstring.Colorize) Locker {
+
+	l := &locker{
+		ctx:     ctx,
+		timeout: timeout,
+		queue:   true,
+		ui:      ui,
+		color:   color,
+	}
+	return l
+}
+
 // Locker locks the given state and outputs to the user if locking is taking
 // longer than the threshold. The lock is retried until the context is
 // cancelled.
@@ -107,14 +132,35 @@ func (l *locker) Lock(s statemgr.Locker, reason string) error {
 
 	l.state = s
 
-	ctx, cancel := context.WithTimeout(l.ctx, l.timeout)
-	defer cancel()
+	ctx := l.ctx
+	if !l.queue || l.timeout > 0 {
+		// Without queueing a zero timeout means a single attempt, while
+		// when queueing it means to wait for as long as it takes.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(l.ctx, l.timeout)
+		defer cancel()
+	}
 
 	lockInfo := state.NewLockInfo()
 	lockInfo.Operation = reason
 
+	var notify func(existing *statemgr.LockInfo)
+	if l.queue {
+		// Tell the user who we're waiting on, but only again when the lock
+		// changes hands so repeated attempts don't flood the UI.
+		reportedID := ""
+		notify = func(existing *statemgr.LockInfo) {
+			if l.ui == nil || existing.ID == reportedID {
+				return
+			}
+			reportedID = existing.ID
+			heldFor := time.Since(existing.Created).Round(time.Second)
+			l.ui.Output(l.color.Color(fmt.Sprintf(LockQueueMessage, existing.Who, existing.Operation, heldFor)))
+		}
+	}
+
 	err := slowmessage.Do(LockThreshold, func() error {
-		id, err := statemgr.LockWithContext(ctx, s, lockInfo)
+		id, err := statemgr.LockWithContextNotify(ctx, s, lockInfo, notify)
 		l.lockID = id
 		return err
 	}, func() {
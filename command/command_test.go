@@ -175,8 +175,10 @@ func testPlan(t *testing.T) *plans.Plan {
 	// This is what an empty configuration block would look like after being
 	// decoded with the schema of the "local" backend.
 	backendConfig := cty.ObjectVal(map[string]cty.Value{
-		"path":          cty.NullVal(cty.String),
-		"workspace_dir": cty.NullVal(cty.String),
+		"path":             cty.NullVal(cty.String),
+		"workspace_dir":    cty.NullVal(cty.String),
+		"backup_dir":       cty.NullVal(cty.String),
+		"backup_retention": cty.NullVal(cty.Number),
 	})
 	backendConfigRaw, err := plans.NewDynamicValue(backendConfig, backendConfig.Type())
 	if err != nil {
@@ -205,7 +207,7 @@ func testPlanFile(t *testing.T, configSnap *configload.Snapshot, state *states.S
 	}
 
 	path := testTempFile(t)
-	err := planfile.Create(path, configSnap, stateFile, plan)
+	err := planfile.Create(path, configSnap, stateFile, plan, nil)
 	if err != nil {
 		t.Fatalf("failed to create temporary plan file: %s", err)
 	}
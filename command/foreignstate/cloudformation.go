@@ -0,0 +1,89 @@
+package foreignstate
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CloudFormationTranslator reads the JSON output of the AWS CLI commands
+// "aws cloudformation describe-stack-resources" and
+// "aws cloudformation list-stack-resources", producing one import operation
+// per stack resource that has a physical identifier.
+//
+// Foreign types are mapped to Terraform resource types using a table of
+// common CloudFormation types; stack resources of other types are returned
+// with an empty Type so that the caller can apply a user-supplied mapping.
+type CloudFormationTranslator struct{}
+
+type cloudFormationDoc struct {
+	StackResources         []cloudFormationResource `json:"StackResources"`
+	StackResourceSummaries []cloudFormationResource `json:"StackResourceSummaries"`
+}
+
+type cloudFormationResource struct {
+	LogicalResourceID  string `json:"LogicalResourceId"`
+	PhysicalResourceID string `json:"PhysicalResourceId"`
+	ResourceType       string `json:"ResourceType"`
+}
+
+// cloudFormationTypes maps common CloudFormation resource types to the
+// corresponding resource types in the official AWS provider.
+var cloudFormationTypes = map[string]string{
+	"AWS::AutoScaling::AutoScalingGroup":        "aws_autoscaling_group",
+	"AWS::CloudWatch::Alarm":                    "aws_cloudwatch_metric_alarm",
+	"AWS::DynamoDB::Table":                      "aws_dynamodb_table",
+	"AWS::EC2::EIP":                             "aws_eip",
+	"AWS::EC2::Instance":                        "aws_instance",
+	"AWS::EC2::InternetGateway":                 "aws_internet_gateway",
+	"AWS::EC2::NatGateway":                      "aws_nat_gateway",
+	"AWS::EC2::RouteTable":                      "aws_route_table",
+	"AWS::EC2::SecurityGroup":                   "aws_security_group",
+	"AWS::EC2::Subnet":                          "aws_subnet",
+	"AWS::EC2::VPC":                             "aws_vpc",
+	"AWS::ECS::Cluster":                         "aws_ecs_cluster",
+	"AWS::ElasticLoadBalancingV2::LoadBalancer": "aws_lb",
+	"AWS::ElasticLoadBalancingV2::TargetGroup":  "aws_lb_target_group",
+	"AWS::IAM::InstanceProfile":                 "aws_iam_instance_profile",
+	"AWS::IAM::Role":                            "aws_iam_role",
+	"AWS::KMS::Key":                             "aws_kms_key",
+	"AWS::Lambda::Function":                     "aws_lambda_function",
+	"AWS::Logs::LogGroup":                       "aws_cloudwatch_log_group",
+	"AWS::RDS::DBInstance":                      "aws_db_instance",
+	"AWS::RDS::DBSubnetGroup":                   "aws_db_subnet_group",
+	"AWS::Route53::HostedZone":                  "aws_route53_zone",
+	"AWS::S3::Bucket":                           "aws_s3_bucket",
+	"AWS::SNS::Topic":                           "aws_sns_topic",
+	"AWS::SQS::Queue":                           "aws_sqs_queue",
+}
+
+// Translate implements Translator.
+func (CloudFormationTranslator) Translate(src []byte) ([]Resource, error) {
+	var doc cloudFormationDoc
+	if err := json.Unmarshal(src, &doc); err != nil {
+		return nil, fmt.Errorf("invalid CloudFormation stack resource document: %s", err)
+	}
+
+	rs := doc.StackResources
+	if len(rs) == 0 {
+		rs = doc.StackResourceSummaries
+	}
+	if len(rs) == 0 {
+		return nil, fmt.Errorf("document contains no stack resources; expected the JSON output of \"aws cloudformation describe-stack-resources\" or \"aws cloudformation list-stack-resources\"")
+	}
+
+	ret := make([]Resource, 0, len(rs))
+	for _, r := range rs {
+		if r.PhysicalResourceID == "" {
+			// Resources that were never successfully created have no
+			// physical identifier, and so nothing to import.
+			continue
+		}
+		ret = append(ret, Resource{
+			Name:        SanitizeName(r.LogicalResourceID),
+			ForeignType: r.ResourceType,
+			Type:        cloudFormationTypes[r.ResourceType],
+			ID:          r.PhysicalResourceID,
+		})
+	}
+	return ret, nil
+}
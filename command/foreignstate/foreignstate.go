@@ -0,0 +1,103 @@
+// Package foreignstate translates resource inventory documents exported by
+// other infrastructure tools into candidate Terraform import operations.
+// It is the engine behind "terraform state import-foreign".
+//
+// Each supported source format has a Translator that parses the foreign
+// document and suggests, for each resource in it, a Terraform resource type,
+// a resource name, and the identifier to pass to the provider's importer.
+// The suggested type mappings are best-effort; callers are expected to let
+// users override them for foreign types that a translator doesn't know or
+// gets wrong.
+package foreignstate
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Resource is one resource from a foreign inventory document, described in
+// terms of the import operation that would bring it under Terraform
+// management.
+type Resource struct {
+	// Name is a suggested Terraform resource name, derived from the
+	// resource's logical name in the source document and sanitized to be a
+	// valid identifier. It is not guaranteed to be unique within the
+	// document.
+	Name string
+
+	// ForeignType is the source system's own type identifier for the
+	// resource, such as "AWS::EC2::Instance", retained so that callers can
+	// report unmapped types and apply user-supplied mappings.
+	ForeignType string
+
+	// Type is the Terraform resource type that the translator mapped the
+	// foreign type to, or empty if the translator has no mapping for it.
+	Type string
+
+	// ID is the identifier to pass to the provider's import handler.
+	ID string
+}
+
+// Translator parses one foreign inventory format.
+type Translator interface {
+	// Translate parses src and returns one Resource per importable
+	// resource found in it. It returns an error only if src cannot be
+	// understood as a document of this translator's format at all;
+	// individual resources that can't be imported (for example, ones with
+	// no physical identifier) are silently skipped.
+	Translate(src []byte) ([]Resource, error)
+}
+
+var translators = map[string]Translator{
+	"cloudformation": CloudFormationTranslator{},
+	"pulumi":         PulumiTranslator{},
+}
+
+// Lookup returns the translator registered under the given format name, or
+// nil if no translator is registered under that name.
+func Lookup(format string) Translator {
+	return translators[format]
+}
+
+// Formats returns the names of all registered translators, sorted.
+func Formats() []string {
+	ret := make([]string, 0, len(translators))
+	for name := range translators {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Register makes a translator available under the given format name, so
+// that additional formats can be linked in by other codebases building on
+// this package. It panics if the name is already taken.
+func Register(format string, t Translator) {
+	if _, exists := translators[format]; exists {
+		panic(fmt.Sprintf("foreignstate: translator %q already registered", format))
+	}
+	translators[format] = t
+}
+
+// SanitizeName converts an arbitrary logical resource name from a foreign
+// document into a valid Terraform resource name, replacing disallowed
+// characters with underscores.
+func SanitizeName(name string) string {
+	if name == "" {
+		return "imported"
+	}
+	b := []byte(name)
+	for i, c := range b {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-':
+			// allowed
+		default:
+			b[i] = '_'
+		}
+	}
+	if c := b[0]; c >= '0' && c <= '9' {
+		// Identifiers can't start with a digit.
+		return "_" + string(b)
+	}
+	return string(b)
+}
@@ -0,0 +1,169 @@
+package foreignstate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCloudFormationTranslate(t *testing.T) {
+	src := []byte(`{
+		"StackResources": [
+			{
+				"LogicalResourceId": "WebServer",
+				"PhysicalResourceId": "i-0123456789abcdef0",
+				"ResourceType": "AWS::EC2::Instance"
+			},
+			{
+				"LogicalResourceId": "Assets",
+				"PhysicalResourceId": "my-assets-bucket",
+				"ResourceType": "AWS::S3::Bucket"
+			},
+			{
+				"LogicalResourceId": "NeverCreated",
+				"PhysicalResourceId": "",
+				"ResourceType": "AWS::EC2::Instance"
+			},
+			{
+				"LogicalResourceId": "Exotic",
+				"PhysicalResourceId": "something",
+				"ResourceType": "AWS::Example::Widget"
+			}
+		]
+	}`)
+
+	got, err := CloudFormationTranslator{}.Translate(src)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []Resource{
+		{
+			Name:        "WebServer",
+			ForeignType: "AWS::EC2::Instance",
+			Type:        "aws_instance",
+			ID:          "i-0123456789abcdef0",
+		},
+		{
+			Name:        "Assets",
+			ForeignType: "AWS::S3::Bucket",
+			Type:        "aws_s3_bucket",
+			ID:          "my-assets-bucket",
+		},
+		{
+			Name:        "Exotic",
+			ForeignType: "AWS::Example::Widget",
+			Type:        "", // unmapped, left for the caller to resolve
+			ID:          "something",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestCloudFormationTranslateSummaries(t *testing.T) {
+	// list-stack-resources wraps the same records in a different key.
+	src := []byte(`{
+		"StackResourceSummaries": [
+			{
+				"LogicalResourceId": "WebServer",
+				"PhysicalResourceId": "i-0123456789abcdef0",
+				"ResourceType": "AWS::EC2::Instance"
+			}
+		]
+	}`)
+
+	got, err := CloudFormationTranslator{}.Translate(src)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 1 || got[0].Type != "aws_instance" {
+		t.Errorf("wrong result: %#v", got)
+	}
+}
+
+func TestCloudFormationTranslateErrors(t *testing.T) {
+	if _, err := (CloudFormationTranslator{}).Translate([]byte(`{`)); err == nil {
+		t.Error("no error for invalid JSON")
+	}
+	if _, err := (CloudFormationTranslator{}).Translate([]byte(`{}`)); err == nil {
+		t.Error("no error for document without stack resources")
+	}
+}
+
+func TestPulumiTranslate(t *testing.T) {
+	src := []byte(`{
+		"deployment": {
+			"resources": [
+				{
+					"urn": "urn:pulumi:prod::infra::pulumi:providers:aws::default",
+					"custom": true,
+					"type": "pulumi:providers:aws",
+					"id": "provider-id"
+				},
+				{
+					"urn": "urn:pulumi:prod::infra::aws:ec2/securityGroup:SecurityGroup::web-sg",
+					"custom": true,
+					"type": "aws:ec2/securityGroup:SecurityGroup",
+					"id": "sg-12345"
+				},
+				{
+					"urn": "urn:pulumi:prod::infra::my:component:Thing::group",
+					"custom": false,
+					"type": "my:component:Thing"
+				}
+			]
+		}
+	}`)
+
+	got, err := PulumiTranslator{}.Translate(src)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []Resource{
+		{
+			Name:        "web-sg",
+			ForeignType: "aws:ec2/securityGroup:SecurityGroup",
+			Type:        "aws_security_group",
+			ID:          "sg-12345",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestPulumiTerraformType(t *testing.T) {
+	tests := []struct {
+		token string
+		want  string
+	}{
+		{"aws:ec2/instance:Instance", "aws_instance"},
+		{"aws:ec2/securityGroup:SecurityGroup", "aws_security_group"},
+		{"random:index/randomId:RandomId", "random_random_id"},
+		{"not-a-token", ""},
+		{":ec2/instance:Instance", ""},
+	}
+	for _, test := range tests {
+		if got := pulumiTerraformType(test.token); got != test.want {
+			t.Errorf("pulumiTerraformType(%q) = %q; want %q", test.token, got, test.want)
+		}
+	}
+}
+
+func TestSanitizeName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"WebServer", "WebServer"},
+		{"web-sg", "web-sg"},
+		{"my.odd name", "my_odd_name"},
+		{"0day", "_0day"},
+		{"", "imported"},
+	}
+	for _, test := range tests {
+		if got := SanitizeName(test.input); got != test.want {
+			t.Errorf("SanitizeName(%q) = %q; want %q", test.input, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package foreignstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PulumiTranslator reads the JSON produced by "pulumi stack export",
+// producing one import operation per custom (non-component) resource that
+// has an identifier.
+//
+// Pulumi providers bridged from Terraform derive their type tokens from the
+// Terraform resource types, so the Terraform type is recovered here by
+// converting the final segment of the token back from camelCase, e.g.
+// "aws:ec2/securityGroup:SecurityGroup" becomes "aws_security_group". This
+// recovery is heuristic: for some resources the token drops part of the
+// Terraform type (for example "aws:s3/bucket:Bucket" is aws_s3_bucket, not
+// aws_bucket), so callers should let users override the suggested types.
+type PulumiTranslator struct{}
+
+type pulumiExport struct {
+	Deployment struct {
+		Resources []pulumiResource `json:"resources"`
+	} `json:"deployment"`
+}
+
+type pulumiResource struct {
+	URN    string `json:"urn"`
+	Custom bool   `json:"custom"`
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+}
+
+// Translate implements Translator.
+func (PulumiTranslator) Translate(src []byte) ([]Resource, error) {
+	var doc pulumiExport
+	if err := json.Unmarshal(src, &doc); err != nil {
+		return nil, fmt.Errorf("invalid Pulumi state export document: %s", err)
+	}
+	if len(doc.Deployment.Resources) == 0 {
+		return nil, fmt.Errorf("document contains no resources; expected the JSON output of \"pulumi stack export\"")
+	}
+
+	var ret []Resource
+	for _, r := range doc.Deployment.Resources {
+		if !r.Custom || r.ID == "" {
+			// Component resources and the stack itself exist only within
+			// Pulumi, so there is nothing to import for them.
+			continue
+		}
+		if strings.HasPrefix(r.Type, "pulumi:") {
+			// Pulumi-internal resources, such as providers.
+			continue
+		}
+		ret = append(ret, Resource{
+			Name:        SanitizeName(pulumiLogicalName(r.URN)),
+			ForeignType: r.Type,
+			Type:        pulumiTerraformType(r.Type),
+			ID:          r.ID,
+		})
+	}
+	return ret, nil
+}
+
+// pulumiLogicalName extracts a resource's logical name from its URN, which
+// has the form "urn:pulumi:stack::project::type::name".
+func pulumiLogicalName(urn string) string {
+	parts := strings.Split(urn, "::")
+	return parts[len(parts)-1]
+}
+
+// pulumiTerraformType derives a Terraform resource type from a Pulumi type
+// token of the form "pkg:module/camelName:Name", returning "" if the token
+// doesn't have that shape.
+func pulumiTerraformType(token string) string {
+	parts := strings.Split(token, ":")
+	if len(parts) != 3 {
+		return ""
+	}
+	pkg, module := parts[0], parts[1]
+	if i := strings.Index(module, "/"); i >= 0 {
+		module = module[i+1:]
+	}
+	if pkg == "" || module == "" {
+		return ""
+	}
+	return pkg + "_" + camelToSnake(module)
+}
+
+func camelToSnake(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
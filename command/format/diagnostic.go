@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hcled"
 	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/terraform/internal/msgcat"
 	"github.com/hashicorp/terraform/tfdiags"
 	"github.com/mitchellh/colorstring"
 	wordwrap "github.com/mitchellh/go-wordwrap"
@@ -33,9 +34,9 @@ func Diagnostic(diag tfdiags.Diagnostic, sources map[string][]byte, color *color
 
 	switch diag.Severity() {
 	case tfdiags.Error:
-		buf.WriteString(color.Color("\n[bold][red]Error: [reset]"))
+		buf.WriteString(color.Color("\n[bold][red]" + msgcat.T("Error:") + " [reset]"))
 	case tfdiags.Warning:
-		buf.WriteString(color.Color("\n[bold][yellow]Warning: [reset]"))
+		buf.WriteString(color.Color("\n[bold][yellow]" + msgcat.T("Warning:") + " [reset]"))
 	default:
 		// Clear out any coloring that might be applied by Terraform's UI helper,
 		// so our result is not context-sensitive.
@@ -80,7 +81,7 @@ func Diagnostic(diag tfdiags.Diagnostic, sources map[string][]byte, color *color
 			// loaded through the main loader. We may load things in other
 			// ways in weird cases, so we'll tolerate it at the expense of
 			// a not-so-helpful error message.
-			fmt.Fprintf(&buf, "  on %s line %d:\n  (source code not available)\n", highlightRange.Filename, highlightRange.Start.Line)
+			fmt.Fprintf(&buf, msgcat.T("  on %s line %d:\n  (source code not available)\n"), highlightRange.Filename, highlightRange.Start.Line)
 		} else {
 			file, offset := parseRange(src, highlightRange)
 
@@ -91,7 +92,7 @@ func Diagnostic(diag tfdiags.Diagnostic, sources map[string][]byte, color *color
 				contextStr = ", in " + contextStr
 			}
 
-			fmt.Fprintf(&buf, "  on %s line %d%s:\n", headerRange.Filename, headerRange.Start.Line, contextStr)
+			fmt.Fprintf(&buf, msgcat.T("  on %s line %d%s:\n"), headerRange.Filename, headerRange.Start.Line, contextStr)
 
 			// Config snippet rendering
 			sc := hcl.NewRangeScanner(src, highlightRange.Filename, bufio.ScanLines)
@@ -197,7 +198,7 @@ func Diagnostic(diag tfdiags.Diagnostic, sources map[string][]byte, color *color
 // be nonsense.
 func DiagnosticWarningsCompact(diags tfdiags.Diagnostics, color *colorstring.Colorize) string {
 	var b strings.Builder
-	b.WriteString(color.Color("[bold][yellow]Warnings:[reset]\n\n"))
+	b.WriteString(color.Color("[bold][yellow]" + msgcat.T("Warnings:") + "[reset]\n\n"))
 	for _, diag := range diags {
 		sources := tfdiags.WarningGroupSourceRanges(diag)
 		b.WriteString(fmt.Sprintf("- %s\n", diag.Description().Summary))
@@ -206,14 +207,14 @@ func DiagnosticWarningsCompact(diags tfdiags.Diagnostics, color *colorstring.Col
 			if mainSource.Subject != nil {
 				if len(sources) > 1 {
 					b.WriteString(fmt.Sprintf(
-						"  on %s line %d (and %d more)\n",
+						msgcat.T("  on %s line %d (and %d more)\n"),
 						mainSource.Subject.Filename,
 						mainSource.Subject.Start.Line,
 						len(sources)-1,
 					))
 				} else {
 					b.WriteString(fmt.Sprintf(
-						"  on %s line %d\n",
+						msgcat.T("  on %s line %d\n"),
 						mainSource.Subject.Filename,
 						mainSource.Subject.Start.Line,
 					))
@@ -70,6 +70,14 @@ func ResourceChange(
 	}
 	buf.WriteString(color.Color("[reset]\n"))
 
+	if change.Description != "" {
+		// The author of the configuration annotated this resource with some
+		// human-oriented context, so we'll show that alongside the change.
+		for _, line := range strings.Split(change.Description, "\n") {
+			buf.WriteString(color.Color(fmt.Sprintf("[bold]  # NOTE:[reset] %s\n", line)))
+		}
+	}
+
 	buf.WriteString(color.Color(DiffActionSymbol(change.Action)) + " ")
 
 	switch addr.Resource.Resource.Mode {
@@ -207,6 +215,21 @@ func (p *blockBodyDiffPrinter) writeBlockBodyDiff(schema *configschema.Block, ol
 
 func (p *blockBodyDiffPrinter) writeAttrDiff(name string, attrS *configschema.Attribute, old, new cty.Value, nameLen, indent int, path cty.Path) {
 	path = append(path, cty.GetAttrStep{Name: name})
+
+	// Values may carry cty marks indicating that a provider considers them
+	// sensitive, in addition to the schema-level Sensitive flag. We redact
+	// in either case, stripping the marks so that the values are safe for
+	// the comparisons below.
+	sensitive := attrS.Sensitive
+	if old.ContainsMarked() {
+		old, _ = old.UnmarkDeep()
+		sensitive = true
+	}
+	if new.ContainsMarked() {
+		new, _ = new.UnmarkDeep()
+		sensitive = true
+	}
+
 	p.buf.WriteString("\n")
 	p.buf.WriteString(strings.Repeat(" ", indent))
 	showJustNew := false
@@ -232,7 +255,7 @@ func (p *blockBodyDiffPrinter) writeAttrDiff(name string, attrS *configschema.At
 	p.buf.WriteString(strings.Repeat(" ", nameLen-len(name)))
 	p.buf.WriteString(" = ")
 
-	if attrS.Sensitive {
+	if sensitive {
 		p.buf.WriteString("(sensitive value)")
 	} else {
 		switch {
@@ -257,6 +280,17 @@ func (p *blockBodyDiffPrinter) writeNestedBlockDiffs(name string, blockS *config
 		return
 	}
 
+	// A sensitive mark may have been promoted onto a whole collection of
+	// nested blocks, as happens for sets because cty sets cannot contain
+	// marked elements. In that case we redact the entire block group
+	// rather than rendering its contents.
+	if old.IsMarked() || new.IsMarked() {
+		p.buf.WriteString("\n")
+		p.buf.WriteString(strings.Repeat(" ", indent+2))
+		p.buf.WriteString(fmt.Sprintf("# %s blocks contain sensitive values and are not shown", name))
+		return
+	}
+
 	// Where old/new are collections representing a nesting mode other than
 	// NestingSingle, we assume the collection value can never be unknown
 	// since we always produce the container for the nested objects, even if
@@ -265,7 +299,11 @@ func (p *blockBodyDiffPrinter) writeNestedBlockDiffs(name string, blockS *config
 	switch blockS.Nesting {
 	case configschema.NestingSingle, configschema.NestingGroup:
 		var action plans.Action
-		eqV := new.Equals(old)
+		// Equality must be decided on unmarked values, since comparing
+		// marked values produces a marked (and thus unusable) result.
+		cmpOld, _ := old.UnmarkDeep()
+		cmpNew, _ := new.UnmarkDeep()
+		eqV := cmpNew.Equals(cmpOld)
 		switch {
 		case old.IsNull():
 			action = plans.Create
@@ -1024,6 +1062,7 @@ func (p *blockBodyDiffPrinter) pathForcesNewResource(path cty.Path) bool {
 
 func ctyEmptyString(value cty.Value) bool {
 	if !value.IsNull() && value.IsKnown() {
+		value, _ := value.Unmark()
 		valueType := value.Type()
 		if valueType == cty.String && value.AsString() == "" {
 			return true
@@ -1,6 +1,8 @@
 package format
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/addrs"
@@ -3120,6 +3122,64 @@ type testCase struct {
 	ExpectedOutput  string
 }
 
+// Values rendered in a diff may carry cty marks indicating sensitivity, in
+// addition to the schema-level Sensitive flag. Marked values can't be
+// round-tripped through a plan file, so we test the body renderer directly
+// here rather than via runTestCases.
+func TestBlockBodyDiff_sensitiveMarks(t *testing.T) {
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"password": {Type: cty.String, Optional: true},
+		},
+		BlockTypes: map[string]*configschema.NestedBlock{
+			"credential": {
+				Nesting: configschema.NestingSet,
+				Block: configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"token": {Type: cty.String, Optional: true},
+					},
+				},
+			},
+		},
+	}
+	credTy := cty.Set(cty.Object(map[string]cty.Type{"token": cty.String}))
+	old := cty.ObjectVal(map[string]cty.Value{
+		"password": cty.StringVal("top-secret").Mark("sensitive"),
+		"credential": cty.SetVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{"token": cty.StringVal("abc123")}),
+		}).Mark("sensitive"),
+	})
+	new := cty.ObjectVal(map[string]cty.Value{
+		"password":   cty.StringVal("even-more-secret").Mark("sensitive"),
+		"credential": cty.NullVal(credTy).Mark("sensitive"),
+	})
+
+	p := blockBodyDiffPrinter{
+		buf: &bytes.Buffer{},
+		color: &colorstring.Colorize{
+			Colors:  colorstring.DefaultColors,
+			Disable: true,
+		},
+		action:          plans.Update,
+		requiredReplace: cty.NewPathSet(),
+	}
+	p.writeBlockBodyDiff(schema, old, new, 6, nil)
+
+	output := p.buf.String()
+	if !strings.Contains(output, "password = (sensitive value)") {
+		t.Errorf("marked attribute value is not redacted\n%s", output)
+	}
+	if strings.Contains(output, "secret") {
+		t.Errorf("sensitive value appears in output\n%s", output)
+	}
+	if !strings.Contains(output, "# credential blocks contain sensitive values and are not shown") {
+		t.Errorf("marked nested block collection is not redacted\n%s", output)
+	}
+	if strings.Contains(output, "abc123") {
+		t.Errorf("sensitive nested block value appears in output\n%s", output)
+	}
+}
+
 func runTestCases(t *testing.T, testCases map[string]testCase) {
 	color := &colorstring.Colorize{Colors: colorstring.DefaultColors, Disable: true}
 
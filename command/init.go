@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
 
@@ -36,8 +39,9 @@ type InitCommand struct {
 
 func (c *InitCommand) Run(args []string) int {
 	var flagFromModule string
-	var flagBackend, flagGet, flagUpgrade bool
+	var flagBackend, flagGet, flagUpgrade, flagGC bool
 	var flagPluginPath FlagStringSlice
+	var flagDevBuild FlagStringSlice
 	var flagVerifyPlugins bool
 	flagConfigExtra := newRawFlags("-backend-config")
 
@@ -46,6 +50,7 @@ func (c *InitCommand) Run(args []string) int {
 	cmdFlags.BoolVar(&flagBackend, "backend", true, "")
 	cmdFlags.Var(flagConfigExtra, "backend-config", "")
 	cmdFlags.StringVar(&flagFromModule, "from-module", "", "copy the source of the given module into the directory before init")
+	cmdFlags.BoolVar(&flagGC, "gc", false, "remove unused providers and modules")
 	cmdFlags.BoolVar(&flagGet, "get", true, "")
 	cmdFlags.BoolVar(&c.getPlugins, "get-plugins", true, "")
 	cmdFlags.BoolVar(&c.forceInitCopy, "force-copy", false, "suppress prompts about copying state data")
@@ -54,6 +59,7 @@ func (c *InitCommand) Run(args []string) int {
 	cmdFlags.BoolVar(&c.reconfigure, "reconfigure", false, "reconfigure")
 	cmdFlags.BoolVar(&flagUpgrade, "upgrade", false, "")
 	cmdFlags.Var(&flagPluginPath, "plugin-dir", "plugin directory")
+	cmdFlags.Var(&flagDevBuild, "dev-build", "provider source directory to build and install")
 	cmdFlags.BoolVar(&flagVerifyPlugins, "verify-plugins", true, "verify plugins")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
@@ -292,7 +298,7 @@ func (c *InitCommand) Run(args []string) int {
 	}
 
 	// Now that we have loaded all modules, check the module tree for missing providers.
-	providersOutput, providerDiags := c.getProviders(earlyConfig, state, flagUpgrade, flagPluginPath)
+	providersOutput, providerDiags := c.getProviders(earlyConfig, state, flagUpgrade, flagPluginPath, flagDevBuild)
 	diags = diags.Append(providerDiags)
 	if providerDiags.HasErrors() {
 		c.showDiagnostics(diags)
@@ -302,6 +308,18 @@ func (c *InitCommand) Run(args []string) int {
 		header = true
 	}
 
+	// With the lock file and module manifest now up to date, we can safely
+	// remove anything they no longer reference.
+	if flagGC {
+		gcDiags := c.gcWorkingDir()
+		diags = diags.Append(gcDiags)
+		if gcDiags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+		header = true
+	}
+
 	// If we outputted information, then we need to output a newline
 	// so that our success message is nicely spaced out from prior text.
 	if header {
@@ -423,7 +441,7 @@ the backend configuration is present and valid.
 
 // Load the complete module tree, and fetch any missing providers.
 // This method outputs its own Ui.
-func (c *InitCommand) getProviders(earlyConfig *earlyconfig.Config, state *states.State, upgrade bool, pluginDirs []string) (output bool, diags tfdiags.Diagnostics) {
+func (c *InitCommand) getProviders(earlyConfig *earlyconfig.Config, state *states.State, upgrade bool, pluginDirs []string, devBuildDirs []string) (output bool, diags tfdiags.Diagnostics) {
 	// First we'll collect all the provider dependencies we can see in the
 	// configuration and the state.
 	reqs, moreDiags := earlyConfig.ProviderRequirements()
@@ -436,6 +454,20 @@ func (c *InitCommand) getProviders(earlyConfig *earlyconfig.Config, state *state
 		reqs = reqs.Merge(stateReqs)
 	}
 
+	// If the user requested any development builds with -dev-build then we
+	// compile and install those before running the normal installer, and
+	// exclude those providers from the requirements we pass to it so that
+	// a dev build always overrides whatever version the configuration or
+	// state would otherwise have selected.
+	devBuilds, devDiags := c.installDevBuildProviders(devBuildDirs)
+	diags = diags.Append(devDiags)
+	if devDiags.HasErrors() {
+		return true, diags
+	}
+	for provider := range devBuilds {
+		delete(reqs, provider)
+	}
+
 	var inst *providercache.Installer
 	if len(pluginDirs) == 0 {
 		// By default we use a source that looks for providers in all of the
@@ -539,6 +571,22 @@ func (c *InitCommand) getProviders(earlyConfig *earlyconfig.Config, state *state
 		return true, diags
 	}
 
+	// Any development builds we installed earlier also need to be recorded
+	// as selections, or else later commands won't know to execute them. We
+	// must do this after EnsureProviderVersions because that function
+	// discards any selections previously recorded in the lock file.
+	for provider, srcDir := range devBuilds {
+		if _, err := inst.SelectDevPackage(provider, devProviderVersion); err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to select development build",
+				fmt.Sprintf("Error while recording the development build of %s for later use: %s.", provider.ForDisplay(), err),
+			))
+			return true, diags
+		}
+		c.Ui.Info(fmt.Sprintf("- Using development build of %s v%s (built from %s)", provider.ForDisplay(), devProviderVersion, srcDir))
+	}
+
 	// If any providers have "floating" versions (completely unconstrained)
 	// we'll suggest the user constrain with a pessimistic constraint to
 	// avoid implicitly adopting a later major release.
@@ -566,6 +614,110 @@ func (c *InitCommand) getProviders(earlyConfig *earlyconfig.Config, state *state
 	return true, diags
 }
 
+// devProviderVersion is the placeholder version number used for provider
+// packages installed from local source code via the -dev-build option.
+var devProviderVersion = getproviders.MustParseVersion("0.0.0-dev")
+
+// installDevBuildProviders compiles provider plugins from the given local
+// source directories using the Go toolchain and installs the results into
+// the configuration-specific provider cache directory, implementing the
+// -dev-build option.
+//
+// Each directory must be named like terraform-provider-NAME, following the
+// usual repository naming convention for providers, because NAME decides
+// which provider address the resulting package is installed as.
+//
+// The result maps each built provider to the source directory it was built
+// from. The caller is responsible for recording the builds as selections in
+// the installer's lock file, via Installer.SelectDevPackage, once any other
+// provider installation is complete.
+func (c *InitCommand) installDevBuildProviders(srcDirs []string) (map[addrs.Provider]string, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	if len(srcDirs) == 0 {
+		return nil, diags
+	}
+
+	cacheDir := c.providerLocalCacheDir()
+	builds := make(map[addrs.Provider]string, len(srcDirs))
+	for _, srcDir := range srcDirs {
+		absDir, err := filepath.Abs(srcDir)
+		if err == nil {
+			info, statErr := os.Stat(absDir)
+			switch {
+			case statErr != nil:
+				err = statErr
+			case !info.IsDir():
+				err = fmt.Errorf("not a directory")
+			}
+		}
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid provider source directory",
+				fmt.Sprintf("Cannot use %q with -dev-build: %s.", srcDir, err),
+			))
+			continue
+		}
+
+		const wantPrefix = "terraform-provider-"
+		base := filepath.Base(absDir)
+		if !strings.HasPrefix(base, wantPrefix) || len(base) == len(wantPrefix) {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid provider source directory",
+				fmt.Sprintf("Cannot use %q with -dev-build: the directory name must have the form terraform-provider-NAME, so that Terraform can determine which provider the build is for.", srcDir),
+			))
+			continue
+		}
+		name, err := addrs.ParseProviderPart(base[len(wantPrefix):])
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid provider source directory",
+				fmt.Sprintf("Cannot use %q with -dev-build: invalid provider name %q: %s.", srcDir, base[len(wantPrefix):], err),
+			))
+			continue
+		}
+		provider := addrs.NewDefaultProvider(name)
+
+		packageDir, err := filepath.Abs(filepath.FromSlash(getproviders.UnpackedDirectoryPathForPackage(
+			cacheDir.BasePath(), provider, devProviderVersion, getproviders.CurrentPlatform,
+		)))
+		if err == nil {
+			err = os.MkdirAll(packageDir, 0755)
+		}
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to prepare provider cache directory",
+				fmt.Sprintf("Error while preparing a cache directory for the development build of %s: %s.", provider.ForDisplay(), err),
+			))
+			continue
+		}
+
+		exeName := wantPrefix + name
+		if runtime.GOOS == "windows" {
+			exeName += ".exe"
+		}
+
+		c.Ui.Info(fmt.Sprintf("- Building %s from %s...", provider.ForDisplay(), srcDir))
+		cmd := exec.Command("go", "build", "-o", filepath.Join(packageDir, exeName), ".")
+		cmd.Dir = absDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Provider development build failed",
+				fmt.Sprintf("Error building %s from %s: %s.\n\n%s", provider.ForDisplay(), srcDir, err, out),
+			))
+			continue
+		}
+
+		builds[provider] = srcDir
+	}
+
+	return builds, diags
+}
+
 // backendConfigOverrideBody interprets the raw values of -backend-config
 // arguments into a hcl Body that should override the backend settings given
 // in the configuration.
@@ -649,8 +801,10 @@ func (c *InitCommand) AutocompleteFlags() complete.Flags {
 	return complete.Flags{
 		"-backend":        completePredictBoolean,
 		"-backend-config": complete.PredictFiles("*.tfvars"), // can also be key=value, but we can't "predict" that
+		"-dev-build":      complete.PredictDirs(""),
 		"-force-copy":     complete.PredictNothing,
 		"-from-module":    completePredictModuleSource,
+		"-gc":             complete.PredictNothing,
 		"-get":            completePredictBoolean,
 		"-get-plugins":    completePredictBoolean,
 		"-input":          completePredictBoolean,
@@ -695,6 +849,14 @@ Options:
                        times. The backend type must be in the configuration
                        itself.
 
+  -dev-build=path      Compile a provider plugin from the given local source
+                       directory using the Go toolchain and install it into
+                       this working directory, overriding any version of that
+                       provider the configuration would otherwise select. The
+                       directory name must have the form
+                       terraform-provider-NAME. This flag can be used
+                       multiple times.
+
   -force-copy          Suppress prompts about copying state data. This is
                        equivalent to providing a "yes" to all confirmation
                        prompts.
@@ -702,6 +864,11 @@ Options:
   -from-module=SOURCE  Copy the contents of the given module into the target
                        directory before initialization.
 
+  -gc                  After initialization succeeds, remove any provider
+                       packages and module directories in this working
+                       directory that the configuration no longer references,
+                       reporting how much space was freed.
+
   -get=true            Download any modules for this configuration.
 
   -get-plugins=true    Download any missing plugins for this configuration.
@@ -0,0 +1,148 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform/internal/modsdir"
+	"github.com/hashicorp/terraform/internal/providercache"
+	"github.com/hashicorp/terraform/tfdiags"
+)
+
+// gcWorkingDir removes provider packages and module directories from the
+// working directory's data dir that are no longer referenced by the
+// provider lock file or the module manifest, reporting what was removed and
+// how much space it freed.
+//
+// This must run only after a successful init pass, so that the lock file
+// and manifest reflect what the configuration currently depends on.
+func (c *InitCommand) gcWorkingDir() tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	c.Ui.Output(c.Colorize().Color("[reset][bold]Garbage collecting unused providers and modules..."))
+
+	var freed int64
+	removed := 0
+
+	cacheDir := c.providerLocalCacheDir()
+	if cacheDir != nil {
+		// The installer is the only component permitted to modify the cache
+		// directory, so pruning happens through it even though we have no
+		// install source here.
+		inst := providercache.NewInstaller(cacheDir, nil)
+		pruned, err := inst.PruneUnselectedPackages()
+		for _, p := range pruned {
+			c.Ui.Output(fmt.Sprintf("- Removed unused provider %s v%s (%s)", p.Provider.ForDisplay(), p.Version, humanReadableBytes(p.Size)))
+			freed += p.Size
+			removed++
+		}
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to prune provider cache",
+				fmt.Sprintf("Error while removing unused provider packages: %s.", err),
+			))
+			return diags
+		}
+	}
+
+	modsFreed, modsRemoved, err := pruneUnusedModuleDirs(filepath.Join(c.DataDir(), "modules"), c.Ui.Output)
+	freed += modsFreed
+	removed += modsRemoved
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to prune module cache",
+			fmt.Sprintf("Error while removing unused module directories: %s.", err),
+		))
+		return diags
+	}
+
+	if removed == 0 {
+		c.Ui.Output("- Nothing to remove")
+	} else {
+		c.Ui.Output(fmt.Sprintf("- Freed %s in total", humanReadableBytes(freed)))
+	}
+	return diags
+}
+
+// pruneUnusedModuleDirs removes any directories under the given modules
+// directory that the module manifest doesn't reference, which are typically
+// copies of remote modules left behind after a module call was removed or
+// its source changed. It reports each removal through output.
+func pruneUnusedModuleDirs(modsDir string, output func(string)) (freed int64, removed int, err error) {
+	manifest, err := modsdir.ReadManifestSnapshotForDir(modsDir)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read module manifest: %s", err)
+	}
+
+	// Remote modules are installed into a subdirectory per module key, while
+	// local modules point outside the modules directory entirely; anything
+	// under the modules directory that no record points into is unused.
+	used := make(map[string]struct{})
+	for _, record := range manifest {
+		rel, err := filepath.Rel(modsDir, record.Dir)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		used[strings.Split(filepath.ToSlash(rel), "/")[0]] = struct{}{}
+	}
+
+	entries, err := ioutil.ReadDir(modsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, 0, nil // no modules directory means nothing to prune
+		}
+		return 0, 0, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			// e.g. the manifest file itself
+			continue
+		}
+		if _, ok := used[entry.Name()]; ok {
+			continue
+		}
+		dir := filepath.Join(modsDir, entry.Name())
+		size := dirSizeBytes(dir)
+		if err := os.RemoveAll(dir); err != nil {
+			return freed, removed, fmt.Errorf("failed to remove %s: %s", dir, err)
+		}
+		output(fmt.Sprintf("- Removed unused module directory %s (%s)", dir, humanReadableBytes(size)))
+		freed += size
+		removed++
+	}
+	return freed, removed, nil
+}
+
+// dirSizeBytes returns the total size in bytes of the files under the given
+// directory, as a best effort; files that can't be scanned are excluded.
+func dirSizeBytes(dir string) int64 {
+	var size int64
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// humanReadableBytes renders a byte count with a binary unit suffix, for
+// the garbage collection report.
+func humanReadableBytes(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1f GiB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
@@ -0,0 +1,99 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform/internal/modsdir"
+)
+
+func TestPruneUnusedModuleDirs(t *testing.T) {
+	td := tempDir(t)
+	if err := os.MkdirAll(td, 0755); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+	modsDir := filepath.Join(td, "modules")
+
+	writeModuleDir := func(name string) {
+		dir := filepath.Join(modsDir, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte("# test\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeModuleDir("in_use")
+	writeModuleDir("stale")
+
+	manifest := modsdir.Manifest{
+		"": {
+			Key: "",
+			Dir: ".",
+		},
+		"in_use": {
+			Key:        "in_use",
+			SourceAddr: "example.com/foo/bar/baz",
+			Dir:        filepath.Join(modsDir, "in_use"),
+		},
+	}
+	if err := manifest.WriteSnapshotToDir(modsDir); err != nil {
+		t.Fatal(err)
+	}
+
+	var msgs []string
+	freed, removed, err := pruneUnusedModuleDirs(modsDir, func(msg string) {
+		msgs = append(msgs, msg)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if removed != 1 {
+		t.Fatalf("wrong number of removed directories %d; want 1\n%#v", removed, msgs)
+	}
+	if freed <= 0 {
+		t.Errorf("no space reported freed")
+	}
+	if _, err := os.Stat(filepath.Join(modsDir, "in_use")); err != nil {
+		t.Errorf("referenced module directory was removed: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(modsDir, "stale")); !os.IsNotExist(err) {
+		t.Errorf("unreferenced module directory still present")
+	}
+	if _, err := os.Stat(filepath.Join(modsDir, modsdir.ManifestSnapshotFilename)); err != nil {
+		t.Errorf("module manifest was removed: %s", err)
+	}
+}
+
+func TestPruneUnusedModuleDirsNoDir(t *testing.T) {
+	// A working directory with no installed modules must be a no-op rather
+	// than an error.
+	freed, removed, err := pruneUnusedModuleDirs(filepath.Join(tempDir(t), "modules"), func(string) {})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if freed != 0 || removed != 0 {
+		t.Errorf("wrong result %d bytes/%d dirs; want 0/0", freed, removed)
+	}
+}
+
+func TestHumanReadableBytes(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{512, "512 B"},
+		{2048, "2.0 KiB"},
+		{5 << 20, "5.0 MiB"},
+		{3 << 30, "3.0 GiB"},
+	}
+	for _, test := range tests {
+		if got := humanReadableBytes(test.size); got != test.want {
+			t.Errorf("humanReadableBytes(%d) = %q; want %q", test.size, got, test.want)
+		}
+	}
+}
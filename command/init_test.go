@@ -7,7 +7,9 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -352,7 +354,7 @@ func TestInit_backendConfigFile(t *testing.T) {
 
 	// Read our saved backend config and verify we have our settings
 	state := testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"hello","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"backup_dir":null,"backup_retention":null,"path":"hello","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 	}
 }
@@ -384,7 +386,7 @@ func TestInit_backendConfigFileChange(t *testing.T) {
 
 	// Read our saved backend config and verify we have our settings
 	state := testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"hello","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"backup_dir":null,"backup_retention":null,"path":"hello","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 	}
 }
@@ -411,7 +413,7 @@ func TestInit_backendConfigKV(t *testing.T) {
 
 	// Read our saved backend config and verify we have our settings
 	state := testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"hello","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"backup_dir":null,"backup_retention":null,"path":"hello","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 	}
 }
@@ -618,7 +620,7 @@ func TestInit_backendReinitWithExtra(t *testing.T) {
 
 	// Read our saved backend config and verify we have our settings
 	state := testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"hello","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"backup_dir":null,"backup_retention":null,"path":"hello","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 	}
 
@@ -631,7 +633,7 @@ func TestInit_backendReinitWithExtra(t *testing.T) {
 		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
 	}
 	state = testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"hello","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"backup_dir":null,"backup_retention":null,"path":"hello","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 	}
 	if state.Backend.Hash != uint64(cHash) {
@@ -660,7 +662,7 @@ func TestInit_backendReinitConfigToExtra(t *testing.T) {
 
 	// Read our saved backend config and verify we have our settings
 	state := testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"foo","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"backup_dir":null,"backup_retention":null,"path":"foo","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 	}
 
@@ -686,7 +688,7 @@ func TestInit_backendReinitConfigToExtra(t *testing.T) {
 		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
 	}
 	state = testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"foo","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"backup_dir":null,"backup_retention":null,"path":"foo","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config after moving to arg\ngot:  %s\nwant: %s", got, want)
 	}
 
@@ -1182,6 +1184,74 @@ func TestInit_providerLockFile(t *testing.T) {
 	}
 }
 
+func TestInit_devBuild(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("-dev-build requires the Go toolchain in PATH")
+	}
+
+	// Create a temporary working directory with a trivial configuration,
+	// since initializing an empty directory skips provider installation
+	// entirely.
+	td := tempDir(t)
+	os.MkdirAll(td, 0755)
+	defer os.RemoveAll(td)
+	defer testChdir(t, td)()
+	if err := ioutil.WriteFile(filepath.Join(td, "main.tf"), []byte("# empty\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A minimal provider source directory for the build to compile. The
+	// result doesn't need to be a working provider because init never
+	// actually executes it.
+	srcDir := filepath.Join(td, "terraform-provider-dev")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(srcDir, "go.mod"), []byte("module terraform-provider-dev\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(srcDir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := new(cli.MockUi)
+	c := &InitCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{"-dev-build=" + srcDir}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	// The build should've been installed into the cache directory under the
+	// placeholder dev version.
+	exePath := fmt.Sprintf(".terraform/plugins/registry.terraform.io/hashicorp/dev/0.0.0-dev/%s/terraform-provider-dev", getproviders.CurrentPlatform)
+	if runtime.GOOS == "windows" {
+		exePath += ".exe"
+	}
+	if _, err := os.Stat(exePath); err != nil {
+		t.Errorf("provider executable was not installed: %s", err)
+	}
+
+	// The build should also be recorded in the selections file so that
+	// later commands will find and execute it.
+	buf, err := ioutil.ReadFile(".terraform/plugins/selections.json")
+	if err != nil {
+		t.Fatalf("failed to read provider selections file: %s", err)
+	}
+	var selections map[string]interface{}
+	if err := json.Unmarshal(buf, &selections); err != nil {
+		t.Fatalf("invalid provider selections file: %s", err)
+	}
+	if _, ok := selections["registry.terraform.io/hashicorp/dev"]; !ok {
+		t.Errorf("no selection recorded for the dev build; selections file contains:\n%s", buf)
+	}
+}
+
 func TestInit_pluginDirReset(t *testing.T) {
 	td := testTempDir(t)
 	defer os.RemoveAll(td)
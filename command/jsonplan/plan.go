@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform/command/jsonstate"
 	"github.com/hashicorp/terraform/configs"
 	"github.com/hashicorp/terraform/plans"
+	"github.com/hashicorp/terraform/plans/planfile"
 	"github.com/hashicorp/terraform/states"
 	"github.com/hashicorp/terraform/states/statefile"
 	"github.com/hashicorp/terraform/terraform"
@@ -37,6 +38,11 @@ type plan struct {
 	OutputChanges   map[string]change `json:"output_changes,omitempty"`
 	PriorState      json.RawMessage   `json:"prior_state,omitempty"`
 	Config          json.RawMessage   `json:"configuration,omitempty"`
+
+	// Timings are the durations of the provider operations performed during
+	// the planning walk, present only when the plan was created with timing
+	// collection enabled.
+	Timings []planfile.ResourceTiming `json:"timings,omitempty"`
 }
 
 func newPlan() *plan {
@@ -91,6 +97,19 @@ func Marshal(
 	p *plans.Plan,
 	sf *statefile.File,
 	schemas *terraform.Schemas,
+) ([]byte, error) {
+	return MarshalWithTimings(config, p, sf, schemas, nil)
+}
+
+// MarshalWithTimings is like Marshal but additionally includes the given
+// operation timings, as recorded in a plan file created with timing
+// collection enabled, under a "timings" key.
+func MarshalWithTimings(
+	config *configs.Config,
+	p *plans.Plan,
+	sf *statefile.File,
+	schemas *terraform.Schemas,
+	timings []planfile.ResourceTiming,
 ) ([]byte, error) {
 	output := newPlan()
 	output.TerraformVersion = version.String()
@@ -132,6 +151,9 @@ func Marshal(
 		return nil, fmt.Errorf("error marshaling config: %s", err)
 	}
 
+	// output.Timings
+	output.Timings = timings
+
 	ret, err := json.Marshal(output)
 	return ret, err
 }
@@ -253,12 +275,19 @@ func (p *plan) marshalResourceChanges(changes *plans.Changes, schemas *terraform
 		r.Name = addr.Resource.Resource.Name
 		r.Type = addr.Resource.Resource.Type
 		r.ProviderName = rc.ProviderAddr.Provider.String()
+		r.Description = rc.Description
 
 		p.ResourceChanges = append(p.ResourceChanges, r)
 
 	}
 
 	sort.Slice(p.ResourceChanges, func(i, j int) bool {
+		if p.ResourceChanges[i].Address == p.ResourceChanges[j].Address {
+			// A current object and any deposed objects for the same
+			// instance share an address, so we use the deposed key as a
+			// tiebreaker to keep the ordering fully deterministic.
+			return p.ResourceChanges[i].Deposed < p.ResourceChanges[j].Deposed
+		}
 		return p.ResourceChanges[i].Address < p.ResourceChanges[j].Address
 	})
 
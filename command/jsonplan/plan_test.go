@@ -5,6 +5,10 @@ import (
 	"testing"
 
 	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/hashicorp/terraform/states"
 )
 
 func TestOmitUnknowns(t *testing.T) {
@@ -262,3 +266,65 @@ func TestUnknownAsBool(t *testing.T) {
 		}
 	}
 }
+
+func TestMarshalResourceChanges_deterministicOrder(t *testing.T) {
+	before, err := plans.NewDynamicValue(cty.ObjectVal(map[string]cty.Value{
+		"woozles": cty.StringVal("foo"),
+		"foozles": cty.StringVal("bar"),
+	}), cty.Object(map[string]cty.Type{
+		"woozles": cty.String,
+		"foozles": cty.String,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mkChange := func(addr string, deposed states.DeposedKey) *plans.ResourceInstanceChangeSrc {
+		return &plans.ResourceInstanceChangeSrc{
+			Addr:       mustAddr(addr),
+			DeposedKey: deposed,
+			ProviderAddr: addrs.AbsProviderConfig{
+				Provider: addrs.NewDefaultProvider("test"),
+				Module:   addrs.RootModule,
+			},
+			ChangeSrc: plans.ChangeSrc{
+				Action: plans.Delete,
+				Before: before,
+				After:  before,
+			},
+		}
+	}
+
+	// Deliberately not in address order, with a deposed object alongside the
+	// current object for one of the instances.
+	changes := &plans.Changes{
+		Resources: []*plans.ResourceInstanceChangeSrc{
+			mkChange("module.child.test_thing.example", states.NotDeposed),
+			mkChange("test_thing.example[1]", states.NotDeposed),
+			mkChange("test_thing.example[0]", states.DeposedKey("00000001")),
+			mkChange("test_thing.example[0]", states.NotDeposed),
+		},
+	}
+
+	var p plan
+	if err := p.marshalResourceChanges(changes, testSchemas()); err != nil {
+		t.Fatal(err)
+	}
+
+	gotOrder := make([]string, len(p.ResourceChanges))
+	for i, rc := range p.ResourceChanges {
+		gotOrder[i] = rc.Address
+		if rc.Deposed != "" {
+			gotOrder[i] += " (deposed " + rc.Deposed + ")"
+		}
+	}
+	wantOrder := []string{
+		"module.child.test_thing.example",
+		"test_thing.example[0]",
+		"test_thing.example[0] (deposed 00000001)",
+		"test_thing.example[1]",
+	}
+	if !reflect.DeepEqual(gotOrder, wantOrder) {
+		t.Errorf("wrong order\ngot:  %#v\nwant: %#v", gotOrder, wantOrder)
+	}
+}
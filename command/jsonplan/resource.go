@@ -61,4 +61,8 @@ type resourceChange struct {
 
 	// Change describes the change that will be made to this object
 	Change change `json:"change,omitempty"`
+
+	// Description is the optional human-readable annotation for the resource
+	// from its configuration, if any. Purely informational.
+	Description string `json:"description,omitempty"`
 }
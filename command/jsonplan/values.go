@@ -141,9 +141,6 @@ func marshalPlannedValues(changes *plans.Changes, schemas *terraform.Schemas) (m
 	if err != nil {
 		return ret, err
 	}
-	sort.Slice(childModules, func(i, j int) bool {
-		return childModules[i].Address < childModules[j].Address
-	})
 
 	ret.ChildModules = childModules
 
@@ -250,5 +247,11 @@ func marshalPlanModules(
 		ret = append(ret, cm)
 	}
 
+	// Sort the child modules by address at every level of the tree, not just
+	// the root, so that the serialized form is deterministic.
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Address < ret[j].Address
+	})
+
 	return ret, nil
 }
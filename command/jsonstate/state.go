@@ -347,6 +347,12 @@ func marshalResources(resources map[string]*states.Resource, module addrs.Module
 	}
 
 	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Address == ret[j].Address {
+			// A current object and any deposed objects for the same
+			// instance share an address, so we use the deposed key as a
+			// tiebreaker to keep the ordering fully deterministic.
+			return ret[i].DeposedKey < ret[j].DeposedKey
+		}
 		return ret[i].Address < ret[j].Address
 	})
 
@@ -131,8 +131,10 @@ type Meta struct {
 	targets []addrs.Targetable
 
 	// Internal fields
-	color bool
-	oldUi cli.Ui
+	color   bool
+	uiTheme string // color theme name selected via -ui-theme or TF_UI_THEME
+	uiASCII bool   // restrict output to ASCII, via -ascii or TF_UI_ASCII
+	oldUi   cli.Ui
 
 	// The fields below are expected to be set by the command via
 	// command line flags. See the Apply command for an example.
@@ -159,6 +161,9 @@ type Meta struct {
 	// stateLockTimeout is the optional duration to retry a state locks locks
 	// when it is already locked by another process.
 	//
+	// stateLockQueue (-lock-wait-queue) waits in line for a held lock,
+	// reporting who holds it, instead of failing immediately.
+	//
 	// forceInitCopy suppresses confirmation for copying state data during
 	// init.
 	//
@@ -173,6 +178,7 @@ type Meta struct {
 	provider         string
 	stateLock        bool
 	stateLockTimeout time.Duration
+	stateLockQueue   bool
 	forceInitCopy    bool
 	reconfigure      bool
 	compactWarnings  bool
@@ -212,9 +218,15 @@ func (m *Meta) StateOutPath() string {
 
 // Colorize returns the colorization structure for a command.
 func (m *Meta) Colorize() *colorstring.Colorize {
+	colors, ok := uiThemeColors(m.uiTheme)
+	if !ok {
+		// An unknown theme name is reported from process; here we just
+		// fall back to the default palette.
+		colors = colorstring.DefaultColors
+	}
 	return &colorstring.Colorize{
-		Colors:  colorstring.DefaultColors,
-		Disable: !m.color,
+		Colors:  colors,
+		Disable: !m.color || colors == nil,
 		Reset:   true,
 	}
 }
@@ -440,15 +452,58 @@ func (m *Meta) process(args []string) []string {
 		}
 	}
 
+	// Set the color theme and the ASCII-only output mode, which like
+	// -no-color are accepted by every command and stripped out here before
+	// command-specific flag parsing. The environment variables provide the
+	// defaults so that CI configurations don't need to edit every command
+	// line.
+	m.uiTheme = os.Getenv(UIThemeEnvVar)
+	m.uiASCII = false
+	if v := os.Getenv(UIASCIIEnvVar); v != "" {
+		if ascii, err := strconv.ParseBool(v); err == nil {
+			m.uiASCII = ascii
+		}
+	}
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "-ascii":
+			m.uiASCII = true
+		case strings.HasPrefix(args[i], "-ui-theme="):
+			m.uiTheme = args[i][len("-ui-theme="):]
+		default:
+			continue
+		}
+		args = append(args[:i], args[i+1:]...)
+		i--
+	}
+	badTheme := ""
+	if _, ok := uiThemeColors(m.uiTheme); !ok {
+		badTheme = m.uiTheme
+		m.uiTheme = ""
+	}
+	if m.uiTheme == "none" {
+		m.color = false
+		m.Color = false
+	}
+
 	// Set the UI
 	m.oldUi = m.Ui
-	m.Ui = &cli.ConcurrentUi{
-		Ui: &ColorizeUi{
-			Colorize:   m.Colorize(),
-			ErrorColor: "[red]",
-			WarnColor:  "[yellow]",
-			Ui:         m.oldUi,
-		},
+	var ui cli.Ui = &ColorizeUi{
+		Colorize:   m.Colorize(),
+		ErrorColor: "[red]",
+		WarnColor:  "[yellow]",
+		Ui:         m.oldUi,
+	}
+	if m.uiASCII {
+		ui = &asciiUi{Ui: ui}
+	}
+	m.Ui = &cli.ConcurrentUi{Ui: ui}
+
+	if badTheme != "" && m.oldUi != nil {
+		m.Ui.Warn(fmt.Sprintf(
+			"Invalid UI theme %q; valid themes are \"dark\", \"light\" and \"none\". Using the default theme.\n",
+			badTheme,
+		))
 	}
 
 	return args
@@ -337,6 +337,7 @@ func (m *Meta) Operation(b backend.Backend) *backend.Operation {
 		Workspace:        workspace,
 		LockState:        m.stateLock,
 		StateLockTimeout: m.stateLockTimeout,
+		StateLockQueue:   m.stateLockQueue,
 	}
 }
 
@@ -1449,8 +1449,10 @@ func TestMetaBackend_planLocal(t *testing.T) {
 	defer testChdir(t, td)()
 
 	backendConfigBlock := cty.ObjectVal(map[string]cty.Value{
-		"path":          cty.NullVal(cty.String),
-		"workspace_dir": cty.NullVal(cty.String),
+		"path":             cty.NullVal(cty.String),
+		"workspace_dir":    cty.NullVal(cty.String),
+		"backup_dir":       cty.NullVal(cty.String),
+		"backup_retention": cty.NullVal(cty.Number),
 	})
 	backendConfigRaw, err := plans.NewDynamicValue(backendConfigBlock, backendConfigBlock.Type())
 	if err != nil {
@@ -1541,8 +1543,10 @@ func TestMetaBackend_planLocalStatePath(t *testing.T) {
 	mark := markStateForMatching(original, "hello")
 
 	backendConfigBlock := cty.ObjectVal(map[string]cty.Value{
-		"path":          cty.NullVal(cty.String),
-		"workspace_dir": cty.NullVal(cty.String),
+		"path":             cty.NullVal(cty.String),
+		"workspace_dir":    cty.NullVal(cty.String),
+		"backup_dir":       cty.NullVal(cty.String),
+		"backup_retention": cty.NullVal(cty.Number),
 	})
 	backendConfigRaw, err := plans.NewDynamicValue(backendConfigBlock, backendConfigBlock.Type())
 	if err != nil {
@@ -1641,8 +1645,10 @@ func TestMetaBackend_planLocalMatch(t *testing.T) {
 	defer testChdir(t, td)()
 
 	backendConfigBlock := cty.ObjectVal(map[string]cty.Value{
-		"path":          cty.NullVal(cty.String),
-		"workspace_dir": cty.NullVal(cty.String),
+		"path":             cty.NullVal(cty.String),
+		"workspace_dir":    cty.NullVal(cty.String),
+		"backup_dir":       cty.NullVal(cty.String),
+		"backup_retention": cty.NullVal(cty.Number),
 	})
 	backendConfigRaw, err := plans.NewDynamicValue(backendConfigBlock, backendConfigBlock.Type())
 	if err != nil {
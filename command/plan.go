@@ -17,8 +17,9 @@ type PlanCommand struct {
 }
 
 func (c *PlanCommand) Run(args []string) int {
-	var destroy, refresh, detailed bool
-	var outPath string
+	var destroy, refresh, detailed, timing, strictVars, forceThreshold bool
+	var explainUnknowns, traceExprShowSensitive bool
+	var outPath, profileDir, traceExpr string
 
 	args = c.Meta.process(args)
 	cmdFlags := c.Meta.extendedFlagSet("plan")
@@ -30,6 +31,16 @@ func (c *PlanCommand) Run(args []string) int {
 	cmdFlags.BoolVar(&detailed, "detailed-exitcode", false, "detailed-exitcode")
 	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
 	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
+	cmdFlags.BoolVar(&c.Meta.stateLockQueue, "lock-wait-queue", false, "wait in line for the state lock")
+	cmdFlags.BoolVar(&timing, "timing", false, "show operation timings")
+	cmdFlags.BoolVar(&strictVars, "strict-vars", false, "error on undeclared variable values")
+	cmdFlags.BoolVar(&forceThreshold, "force-threshold", false, "override max_destroy and max_changes limits")
+	cmdFlags.StringVar(&traceExpr, "trace-expr", "", "trace the derivation of a resource attribute value")
+	cmdFlags.BoolVar(&traceExprShowSensitive, "trace-expr-show-sensitive", false, "show sensitive values in -trace-expr output")
+	cmdFlags.BoolVar(&explainUnknowns, "explain-unknowns", false, "report which references caused values to be unknown")
+	// -profile-dir is intentionally undocumented; it exists for gathering
+	// diagnostic data to attach to performance issue reports.
+	cmdFlags.StringVar(&profileDir, "profile-dir", "", "write pprof profiles to this directory")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
@@ -92,8 +103,15 @@ func (c *PlanCommand) Run(args []string) int {
 	opReq := c.Operation(b)
 	opReq.ConfigDir = configPath
 	opReq.Destroy = destroy
+	opReq.ExplainUnknowns = explainUnknowns
+	opReq.ForceThreshold = forceThreshold
 	opReq.PlanOutPath = outPath
 	opReq.PlanRefresh = refresh
+	opReq.ShowTimings = timing
+	opReq.ProfileDir = profileDir
+	opReq.StrictVariables = strictVars
+	opReq.TraceExpr = traceExpr
+	opReq.TraceExprShowSensitive = traceExprShowSensitive
 	opReq.Type = backend.OperationTypePlan
 
 	opReq.ConfigLoader, err = c.initConfigLoader()
@@ -197,6 +215,10 @@ Usage: terraform plan [options] [DIR]
 
 Options:
 
+  -ascii              Restrict output to ASCII characters, for terminals and
+                      CI log consoles that can't render anything else. May
+                      also be set via the TF_UI_ASCII environment variable.
+
   -compact-warnings   If Terraform produces any warnings that are not
                       accompanied by errors, show them in a more compact form
                       that includes only the summary messages.
@@ -210,12 +232,23 @@ Options:
                       1 - Errored
                       2 - Succeeded, there is a diff
 
+  -explain-unknowns   After the plan, report which upstream reference caused
+                      each "(known after apply)" value to be unknown.
+
+  -force-threshold    Proceed even if the plan exceeds a max_destroy or
+                      max_changes limit set in the configuration.
+
   -input=true         Ask for input for variables if not directly set.
 
   -lock=true          Lock the state file when locking is supported.
 
   -lock-timeout=0s    Duration to retry a state lock.
 
+  -lock-wait-queue    Instead of failing when the state is already locked,
+                      wait in line for the lock to be released, periodically
+                      reporting who currently holds it. With no -lock-timeout
+                      the wait is unbounded.
+
   -no-color           If specified, output won't contain any color.
 
   -out=path           Write a plan file to the given path. This can be used as
@@ -229,10 +262,35 @@ Options:
                       up Terraform-managed resources. By default it will
                       use the state "terraform.tfstate" if it exists.
 
+  -strict-vars        Treat values provided for undeclared variables (via
+                      -var, -var-file, or TF_VAR_ environment variables) as
+                      errors, and never prompt for missing required variables.
+
   -target=resource    Resource to target. Operation will be limited to this
                       resource and its dependencies. This flag can be used
                       multiple times.
 
+  -trace-expr=ADDR.attribute
+                      Report how the value of the given resource attribute
+                      was derived: its source expression, the values of the
+                      references it makes, and the functions it applies.
+                      Sensitive values are redacted from the report.
+
+  -trace-expr-show-sensitive
+                      Include sensitive values in the -trace-expr report
+                      instead of redacting them.
+
+  -timing             Show a report of per-resource provider operation
+                      durations after the plan completes, listing the slowest
+                      resources and providers. When combined with -out, the
+                      timings are also saved in the plan file and appear
+                      under a "timings" key in "terraform show -json" output.
+
+  -ui-theme=name      Select the color theme for output: "dark" (the
+                      default), "light" for light terminal backgrounds, or
+                      "none" to disable color like -no-color. May also be
+                      set via the TF_UI_THEME environment variable.
+
   -var 'foo=bar'      Set a variable in the Terraform configuration. This
                       flag can be set multiple times.
 
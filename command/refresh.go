@@ -24,6 +24,7 @@ func (c *RefreshCommand) Run(args []string) int {
 	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
 	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
 	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
+	cmdFlags.BoolVar(&c.Meta.stateLockQueue, "lock-wait-queue", false, "wait in line for the state lock")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
@@ -130,6 +131,11 @@ Options:
 
   -lock-timeout=0s    Duration to retry a state lock.
 
+  -lock-wait-queue    Instead of failing when the state is already locked,
+                      wait in line for the lock to be released, periodically
+                      reporting who currently holds it. With no -lock-timeout
+                      the wait is unbounded.
+
   -no-color           If specified, output won't contain any color.
 
   -state=path         Path to read and save state (unless state-out
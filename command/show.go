@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/hashicorp/terraform/addrs"
 	"github.com/hashicorp/terraform/backend"
 	localBackend "github.com/hashicorp/terraform/backend/local"
 	"github.com/hashicorp/terraform/command/format"
@@ -35,12 +36,27 @@ func (c *ShowCommand) Run(args []string) int {
 	}
 
 	args = cmdFlags.Args()
-	if len(args) > 2 {
-		c.Ui.Error(
-			"The show command expects at most two arguments.\n The path to a " +
-				"Terraform state or plan file, and optionally -json for json output.\n")
-		cmdFlags.Usage()
-		return 1
+
+	// The first argument, if it names an existing file, is the path to a
+	// state or plan file. Any remaining arguments (or all of the arguments,
+	// if the first is not a file) are resource or module addresses used to
+	// filter the output.
+	var path string
+	if len(args) > 0 {
+		switch _, err := os.Stat(args[0]); {
+		case err == nil:
+			path = args[0]
+			args = args[1:]
+		case looksLikeShowFilePath(args[0]) || !os.IsNotExist(err):
+			// An argument that was clearly intended as a file path must be
+			// an error rather than being silently reinterpreted as an
+			// address filter, since a typo'd path would otherwise produce
+			// empty output with a success exit status.
+			c.Ui.Error(fmt.Sprintf(
+				"Terraform couldn't read the given file as a state or plan file.\n\n"+
+					"Read error: %s", err))
+			return 1
+		}
 	}
 
 	// Check for user-supplied plugin path
@@ -52,6 +68,17 @@ func (c *ShowCommand) Run(args []string) int {
 
 	var diags tfdiags.Diagnostics
 
+	var showTargets []addrs.Targetable
+	for _, arg := range args {
+		target, targetDiags := addrs.ParseTargetStr(arg)
+		if targetDiags.HasErrors() {
+			diags = diags.Append(targetDiags)
+			c.showDiagnostics(diags)
+			return 1
+		}
+		showTargets = append(showTargets, target.Subject)
+	}
+
 	// Load the backend
 	b, backendDiags := c.Backend(nil)
 	diags = diags.Append(backendDiags)
@@ -77,11 +104,11 @@ func (c *ShowCommand) Run(args []string) int {
 
 	// Determine if a planfile was passed to the command
 	var planFile *planfile.Reader
-	if len(args) > 0 {
+	if path != "" {
 		// We will handle error checking later on - this is just required to
 		// load the local context if the given path is successfully read as
 		// a planfile.
-		planFile, _ = c.PlanFile(args[0])
+		planFile, _ = c.PlanFile(path)
 	}
 
 	// Build the operation
@@ -109,13 +136,14 @@ func (c *ShowCommand) Run(args []string) int {
 
 	var planErr, stateErr error
 	var plan *plans.Plan
+	var planMeta *planfile.Metadata
+	var planTimings []planfile.ResourceTiming
 	var stateFile *statefile.File
 
 	// if a path was provided, try to read it as a path to a planfile
 	// if that fails, try to read the cli argument as a path to a statefile
-	if len(args) > 0 {
-		path := args[0]
-		plan, stateFile, planErr = getPlanFromPath(path)
+	if path != "" {
+		plan, stateFile, planMeta, planTimings, planErr = getPlanFromPath(path)
 		if planErr != nil {
 			stateFile, stateErr = getStateFromPath(path)
 			if stateErr != nil {
@@ -138,10 +166,21 @@ func (c *ShowCommand) Run(args []string) int {
 		}
 	}
 
+	// If address filters were given, reduce the plan and state to just the
+	// matching resources before rendering.
+	if len(showTargets) != 0 {
+		if plan != nil {
+			plan = filterPlanChanges(plan, showTargets)
+		}
+		if stateFile != nil {
+			stateFile = filterStateFile(stateFile, showTargets)
+		}
+	}
+
 	if plan != nil {
 		if jsonOutput == true {
 			config := ctx.Config()
-			jsonPlan, err := jsonplan.Marshal(config, plan, stateFile, schemas)
+			jsonPlan, err := jsonplan.MarshalWithTimings(config, plan, stateFile, schemas, planTimings)
 
 			if err != nil {
 				c.Ui.Error(fmt.Sprintf("Failed to marshal plan to json: %s", err))
@@ -151,6 +190,10 @@ func (c *ShowCommand) Run(args []string) int {
 			return 0
 		}
 
+		if planMeta != nil {
+			c.Ui.Output(formatPlanMetadata(planMeta))
+		}
+
 		// FIXME: We currently call into the local backend for this, since
 		// the "terraform plan" logic lives there and our package call graph
 		// means we can't orient this dependency the other way around. In
@@ -189,11 +232,15 @@ func (c *ShowCommand) Run(args []string) int {
 
 func (c *ShowCommand) Help() string {
 	helpText := `
-Usage: terraform show [options] [path]
+Usage: terraform show [options] [path] [addresses...]
 
   Reads and outputs a Terraform state or plan file in a human-readable
   form. If no path is specified, the current state will be shown.
 
+  If one or more resource or module addresses are given, only the matching
+  resources are included in the output. This works for both state and plan
+  files, and for both the human-readable and -json forms.
+
 Options:
 
   -no-color           If specified, output won't contain any color.
@@ -208,21 +255,127 @@ func (c *ShowCommand) Synopsis() string {
 	return "Inspect Terraform state or plan"
 }
 
-// getPlanFromPath returns a plan and statefile if the user-supplied path points
-// to a planfile. If both plan and error are nil, the path is likely a
-// directory. An error could suggest that the given path points to a statefile.
-func getPlanFromPath(path string) (*plans.Plan, *statefile.File, error) {
+// getPlanFromPath returns a plan, statefile and plan metadata if the
+// user-supplied path points to a planfile. If both plan and error are nil,
+// the path is likely a directory. An error could suggest that the given path
+// points to a statefile. The metadata may be nil even on success, since plan
+// files created by earlier versions of Terraform don't carry any.
+func getPlanFromPath(path string) (*plans.Plan, *statefile.File, *planfile.Metadata, []planfile.ResourceTiming, error) {
 	pr, err := planfile.Open(path)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	plan, err := pr.ReadPlan()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, nil, err
+	}
+
+	meta, err := pr.ReadMetadata()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	timings, err := pr.ReadTimings()
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
 
 	stateFile, err := pr.ReadStateFile()
-	return plan, stateFile, err
+	return plan, stateFile, meta, timings, err
+}
+
+// formatPlanMetadata renders the provenance metadata from a plan file for
+// display above the plan itself, skipping any fields that weren't recorded.
+func formatPlanMetadata(meta *planfile.Metadata) string {
+	var buf strings.Builder
+	buf.WriteString("Plan metadata:\n")
+	if !meta.CreatedAt.IsZero() {
+		fmt.Fprintf(&buf, "  Created:           %s\n", meta.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+	}
+	if meta.CreatedBy != "" {
+		fmt.Fprintf(&buf, "  Created by:        %s\n", meta.CreatedBy)
+	}
+	if meta.TerraformVersion != "" {
+		fmt.Fprintf(&buf, "  Terraform version: %s\n", meta.TerraformVersion)
+	}
+	if meta.Workspace != "" {
+		fmt.Fprintf(&buf, "  Workspace:         %s\n", meta.Workspace)
+	}
+	if meta.GitCommit != "" {
+		fmt.Fprintf(&buf, "  Git commit:        %s\n", meta.GitCommit)
+	}
+	if meta.ConfigHash != "" {
+		fmt.Fprintf(&buf, "  Config hash:       %s\n", meta.ConfigHash)
+	}
+	return buf.String()
+}
+
+// filterPlanChanges returns a shallow copy of the given plan whose resource
+// changes are limited to those matched by the given target addresses.
+func filterPlanChanges(plan *plans.Plan, targets []addrs.Targetable) *plans.Plan {
+	ret := *plan
+	changes := *plan.Changes
+	changes.Resources = nil
+	for _, rc := range plan.Changes.Resources {
+		if matchesAnyTarget(targets, rc.Addr) {
+			changes.Resources = append(changes.Resources, rc)
+		}
+	}
+	ret.Changes = &changes
+	return &ret
+}
+
+// filterStateFile returns a shallow copy of the given state file whose state
+// retains only the resource instances matched by the given target addresses.
+func filterStateFile(file *statefile.File, targets []addrs.Targetable) *statefile.File {
+	if file.State == nil {
+		return file
+	}
+	ret := *file
+	state := file.State.DeepCopy()
+	for moduleKey, ms := range state.Modules {
+		for resourceKey, rs := range ms.Resources {
+			for key := range rs.Instances {
+				if !matchesAnyTarget(targets, rs.Addr.Instance(key)) {
+					delete(rs.Instances, key)
+				}
+			}
+			if len(rs.Instances) == 0 {
+				delete(ms.Resources, resourceKey)
+			}
+		}
+		if len(ms.Resources) == 0 && !ms.Addr.IsRoot() {
+			delete(state.Modules, moduleKey)
+		}
+	}
+	ret.State = state
+	return &ret
+}
+
+func matchesAnyTarget(targets []addrs.Targetable, addr addrs.AbsResourceInstance) bool {
+	for _, target := range targets {
+		if target.TargetContains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikeShowFilePath decides whether an argument to "terraform show" that
+// doesn't name an existing file was nonetheless intended as a file path
+// rather than an address filter: anything with a path separator or one of
+// the conventional state/plan file suffixes, none of which can appear in a
+// valid resource or module address.
+func looksLikeShowFilePath(arg string) bool {
+	if strings.ContainsAny(arg, `/\`) {
+		return true
+	}
+	for _, suffix := range []string{".tfstate", ".tfplan", ".backup"} {
+		if strings.HasSuffix(arg, suffix) {
+			return true
+		}
+	}
+	return false
 }
 
 // getStateFromPath returns a statefile if the user-supplied path points to a statefile.
@@ -39,6 +39,28 @@ func TestShow(t *testing.T) {
 	}
 }
 
+func TestShow_mistypedFilePath(t *testing.T) {
+	// An argument that looks like a file path but doesn't name an existing
+	// file must be an error, rather than being reinterpreted as an address
+	// filter that silently matches nothing.
+	for _, arg := range []string{"nonexist.tfstate", "nonexist.tfplan", "nonexist.backup", "subdir/nonexist"} {
+		ui := new(cli.MockUi)
+		c := &ShowCommand{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(testProvider()),
+				Ui:               ui,
+			},
+		}
+
+		if code := c.Run([]string{arg}); code != 1 {
+			t.Fatalf("unexpected exit status %d for %q: \n%s", code, arg, ui.OutputWriter.String())
+		}
+		if got, want := ui.ErrorWriter.String(), "couldn't read the given file"; !strings.Contains(got, want) {
+			t.Fatalf("unexpected output for %q: \n%s", arg, got)
+		}
+	}
+}
+
 func TestShow_noArgs(t *testing.T) {
 	// Create the default state
 	statePath := testStateFile(t, testState())
@@ -229,6 +251,100 @@ func TestShow_state(t *testing.T) {
 	}
 }
 
+func TestShow_stateFilterAddrs(t *testing.T) {
+	state := states.BuildState(func(s *states.SyncState) {
+		for _, name := range []string{"foo", "bar"} {
+			s.SetResourceInstanceCurrent(
+				addrs.Resource{
+					Mode: addrs.ManagedResourceMode,
+					Type: "test_instance",
+					Name: name,
+				}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+				&states.ResourceInstanceObjectSrc{
+					AttrsJSON:    []byte(`{"id":"` + name + `"}`),
+					Status:       states.ObjectReady,
+					Dependencies: []addrs.ConfigResource{},
+					DependsOn:    []addrs.Referenceable{},
+				},
+				addrs.AbsProviderConfig{
+					Provider: addrs.NewDefaultProvider("test"),
+					Module:   addrs.RootModule,
+				},
+			)
+		}
+	})
+	statePath := testStateFile(t, state)
+	defer os.RemoveAll(filepath.Dir(statePath))
+
+	ui := new(cli.MockUi)
+	c := &ShowCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{
+		statePath,
+		"test_instance.bar",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	got := ui.OutputWriter.String()
+	if !strings.Contains(got, "# test_instance.bar:") {
+		t.Errorf("missing test_instance.bar from output:\n%s", got)
+	}
+	if strings.Contains(got, "# test_instance.foo:") {
+		t.Errorf("test_instance.foo should have been filtered out:\n%s", got)
+	}
+}
+
+func TestShow_planFilterAddrs(t *testing.T) {
+	planPath := showFixturePlanFile(t, plans.Create)
+
+	ui := cli.NewMockUi()
+	c := &ShowCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(showFixtureProvider()),
+			Ui:               ui,
+		},
+	}
+
+	// The fixture plan only contains test_instance.foo, so filtering for a
+	// different address should produce no resource changes.
+	args := []string{
+		planPath,
+		"test_instance.boop",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+	if got, dontWant := ui.OutputWriter.String(), "test_instance.foo"; strings.Contains(got, dontWant) {
+		t.Errorf("test_instance.foo should have been filtered out:\n%s", got)
+	}
+
+	// Filtering for the address that is in the plan should keep it.
+	ui = cli.NewMockUi()
+	c = &ShowCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(showFixtureProvider()),
+			Ui:               ui,
+		},
+	}
+	args = []string{
+		planPath,
+		"test_instance.foo",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+	if got, want := ui.OutputWriter.String(), "test_instance.foo will be created"; !strings.Contains(got, want) {
+		t.Errorf("missing expected output\nwant: %s\ngot:\n%s", want, got)
+	}
+}
+
 func TestShow_json_output(t *testing.T) {
 	fixtureDir := "testdata/show-json"
 	testDirs, err := ioutil.ReadDir(fixtureDir)
@@ -0,0 +1,307 @@
+package command
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// StateExportCommand is a Command implementation that exports a flat
+// inventory of the resource instances in the state, suitable for ingestion
+// into asset inventory or CMDB systems.
+type StateExportCommand struct {
+	Meta
+	StateMeta
+}
+
+// stateExportRecord is one row of the exported inventory.
+type stateExportRecord struct {
+	Address    string                 `json:"address"`
+	Module     string                 `json:"module,omitempty"`
+	Mode       string                 `json:"mode"`
+	Type       string                 `json:"type"`
+	Name       string                 `json:"name"`
+	Index      string                 `json:"index,omitempty"`
+	Provider   string                 `json:"provider"`
+	ID         string                 `json:"id,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+func (c *StateExportCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var format string
+	cmdFlags := c.Meta.defaultFlagSet("state export")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", "", "path")
+	cmdFlags.StringVar(&format, "format", "json", "output format")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+
+	if format != "json" && format != "csv" {
+		c.Ui.Error(fmt.Sprintf("Unsupported format %q: must be either \"json\" or \"csv\".", format))
+		return 1
+	}
+
+	// Check for user-supplied plugin path
+	var err error
+	if c.pluginPath, err = c.loadPluginPath(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error loading plugin path: %s", err))
+		return 1
+	}
+
+	// Load the backend
+	b, backendDiags := c.Backend(nil)
+	if backendDiags.HasErrors() {
+		c.showDiagnostics(backendDiags)
+		return 1
+	}
+
+	// We require a local backend
+	local, ok := b.(backend.Local)
+	if !ok {
+		c.Ui.Error(ErrUnsupportedLocalOp)
+		return 1
+	}
+
+	// We expect the config dir to always be the cwd
+	cwd, err := os.Getwd()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error getting cwd: %s", err))
+		return 1
+	}
+
+	// Build the operation (required to get the schemas)
+	opReq := c.Operation(b)
+	opReq.AllowUnsetVariables = true
+	opReq.ConfigDir = cwd
+
+	opReq.ConfigLoader, err = c.initConfigLoader()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing config loader: %s", err))
+		return 1
+	}
+
+	// Get the context (required to get the schemas)
+	ctx, _, ctxDiags := local.Context(opReq)
+	if ctxDiags.HasErrors() {
+		c.showDiagnostics(ctxDiags)
+		return 1
+	}
+
+	// Get the schemas from the context, for sensitive value redaction
+	schemas := ctx.Schemas()
+
+	// Get the state
+	env := c.Workspace()
+	stateMgr, err := b.StateMgr(env)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return 1
+	}
+	if err := stateMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to refresh state: %s", err))
+		return 1
+	}
+
+	state := stateMgr.State()
+	if state == nil {
+		c.Ui.Error(fmt.Sprintf(errStateNotFound))
+		return 1
+	}
+
+	records := stateExportRecords(state, schemas)
+
+	switch format {
+	case "json":
+		js, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to serialize inventory: %s", err))
+			return 1
+		}
+		c.Ui.Output(string(js))
+	case "csv":
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.Write([]string{"address", "module", "mode", "type", "name", "index", "provider", "id", "attributes"})
+		for _, record := range records {
+			attrs := ""
+			if record.Attributes != nil {
+				js, err := json.Marshal(record.Attributes)
+				if err != nil {
+					c.Ui.Error(fmt.Sprintf("Failed to serialize attributes of %s: %s", record.Address, err))
+					return 1
+				}
+				attrs = string(js)
+			}
+			w.Write([]string{
+				record.Address,
+				record.Module,
+				record.Mode,
+				record.Type,
+				record.Name,
+				record.Index,
+				record.Provider,
+				record.ID,
+				attrs,
+			})
+		}
+		w.Flush()
+		c.Ui.Output(strings.TrimSuffix(buf.String(), "\n"))
+	}
+
+	return 0
+}
+
+// stateExportRecords produces one record per current resource instance
+// object in the state, sorted by address, with sensitive attribute values
+// redacted using the given schemas.
+func stateExportRecords(state *states.State, schemas *terraform.Schemas) []*stateExportRecord {
+	var records []*stateExportRecord
+
+	for _, ms := range state.Modules {
+		moduleAddr := ""
+		if !ms.Addr.IsRoot() {
+			moduleAddr = ms.Addr.String()
+		}
+
+		for _, rs := range ms.Resources {
+			mode := "managed"
+			if rs.Addr.Resource.Mode == addrs.DataResourceMode {
+				mode = "data"
+			}
+
+			var schema *configschema.Block
+			if schemas != nil {
+				schema, _ = schemas.ResourceTypeConfig(rs.ProviderConfig.Provider, rs.Addr.Resource.Mode, rs.Addr.Resource.Type)
+			}
+
+			for key, inst := range rs.Instances {
+				if !inst.HasCurrent() {
+					continue
+				}
+
+				index := ""
+				if key != addrs.NoKey {
+					index = strings.Trim(key.String(), "[]\"")
+				}
+
+				records = append(records, &stateExportRecord{
+					Address:    rs.Addr.Instance(key).String(),
+					Module:     moduleAddr,
+					Mode:       mode,
+					Type:       rs.Addr.Resource.Type,
+					Name:       rs.Addr.Resource.Name,
+					Index:      index,
+					Provider:   rs.ProviderConfig.Provider.String(),
+					ID:         states.LegacyInstanceObjectID(inst.Current),
+					Attributes: exportAttributes(inst.Current, schema),
+				})
+			}
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Address < records[j].Address
+	})
+	return records
+}
+
+// exportAttributes returns the given instance object's attributes with any
+// values the schema marks as sensitive replaced by a redaction marker. If
+// no schema is available we cannot tell which values are sensitive, so no
+// attributes are exported at all.
+func exportAttributes(obj *states.ResourceInstanceObjectSrc, schema *configschema.Block) map[string]interface{} {
+	if schema == nil || obj.AttrsJSON == nil {
+		return nil
+	}
+
+	var attrs map[string]interface{}
+	if err := json.Unmarshal(obj.AttrsJSON, &attrs); err != nil {
+		return nil
+	}
+
+	redactSensitive(attrs, schema)
+	return attrs
+}
+
+// redactSensitive replaces the values of schema-sensitive attributes in
+// attrs, recursing into nested blocks.
+func redactSensitive(attrs map[string]interface{}, schema *configschema.Block) {
+	for name, attrS := range schema.Attributes {
+		if !attrS.Sensitive {
+			continue
+		}
+		if v, ok := attrs[name]; ok && v != nil {
+			attrs[name] = "(sensitive value)"
+		}
+	}
+
+	for name, blockS := range schema.BlockTypes {
+		raw, ok := attrs[name]
+		if !ok {
+			continue
+		}
+		switch body := raw.(type) {
+		case map[string]interface{}:
+			switch blockS.Nesting {
+			case configschema.NestingMap:
+				// each value of the map is one block
+				for _, elem := range body {
+					if block, ok := elem.(map[string]interface{}); ok {
+						redactSensitive(block, &blockS.Block)
+					}
+				}
+			default:
+				redactSensitive(body, &blockS.Block)
+			}
+		case []interface{}:
+			for _, elem := range body {
+				if block, ok := elem.(map[string]interface{}); ok {
+					redactSensitive(block, &blockS.Block)
+				}
+			}
+		}
+	}
+}
+
+func (c *StateExportCommand) Help() string {
+	helpText := `
+Usage: terraform state export [options]
+
+  Export a flat inventory of the resources in the Terraform state.
+
+  This command produces one record per resource instance in the state,
+  with its address, module, type, provider and id, plus the instance's
+  attributes with sensitive values redacted. The output is suitable for
+  ingestion into asset inventory or CMDB systems without needing a custom
+  parser for the state format.
+
+Options:
+
+  -state=statefile    Path to a Terraform state file to use to look
+                      up Terraform-managed resources. By default, Terraform
+                      will consult the state of the currently-selected
+                      workspace.
+
+  -format=json        Output format, either "json" (the default) or "csv".
+                      The csv output serializes each record's attributes
+                      as JSON in the final column.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateExportCommand) Synopsis() string {
+	return "Export a flat inventory of resources in the state"
+}
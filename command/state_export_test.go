@@ -0,0 +1,250 @@
+package command
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/mitchellh/cli"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func testStateExportState() *states.State {
+	submod, _ := addrs.ParseModuleInstanceStr("module.sub")
+	return states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(
+			addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_instance",
+				Name: "foo",
+			}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"bar","foo":"value","password":"hunter2"}`),
+				Status:    states.ObjectReady,
+			},
+			addrs.AbsProviderConfig{
+				Provider: addrs.NewDefaultProvider("test"),
+				Module:   addrs.RootModule,
+			},
+		)
+		s.SetResourceInstanceCurrent(
+			addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_instance",
+				Name: "foo",
+			}.Instance(addrs.IntKey(0)).Absolute(submod),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"sub","foo":"value","password":"hunter2"}`),
+				Status:    states.ObjectReady,
+			},
+			addrs.AbsProviderConfig{
+				Provider: addrs.NewDefaultProvider("test"),
+				Module:   submod.Module(),
+			},
+		)
+	})
+}
+
+func testStateExportProviderSchema() *terraform.ProviderSchema {
+	return &terraform.ProviderSchema{
+		ResourceTypes: map[string]*configschema.Block{
+			"test_instance": {
+				Attributes: map[string]*configschema.Attribute{
+					"id":       {Type: cty.String, Optional: true, Computed: true},
+					"foo":      {Type: cty.String, Optional: true},
+					"password": {Type: cty.String, Optional: true, Sensitive: true},
+				},
+			},
+		},
+	}
+}
+
+func TestStateExport_json(t *testing.T) {
+	statePath := testStateFile(t, testStateExportState())
+
+	p := testProvider()
+	p.GetSchemaReturn = testStateExportProviderSchema()
+
+	ui := new(cli.MockUi)
+	c := &StateExportCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	var records []*stateExportRecord
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &records); err != nil {
+		t.Fatalf("output is not valid JSON: %s", err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	// records must be sorted by address
+	first, second := records[0], records[1]
+	if first.Address != "module.sub.test_instance.foo[0]" {
+		t.Errorf("wrong first address %q", first.Address)
+	}
+	if first.Module != "module.sub" {
+		t.Errorf("wrong module %q", first.Module)
+	}
+	if first.Index != "0" {
+		t.Errorf("wrong index %q", first.Index)
+	}
+	if first.ID != "sub" {
+		t.Errorf("wrong id %q", first.ID)
+	}
+
+	if second.Address != "test_instance.foo" {
+		t.Errorf("wrong second address %q", second.Address)
+	}
+	if second.Module != "" {
+		t.Errorf("unexpected module %q for root resource", second.Module)
+	}
+	if second.Mode != "managed" {
+		t.Errorf("wrong mode %q", second.Mode)
+	}
+	if second.Type != "test_instance" || second.Name != "foo" {
+		t.Errorf("wrong type/name %q.%q", second.Type, second.Name)
+	}
+	if second.Provider != `registry.terraform.io/hashicorp/test` {
+		t.Errorf("wrong provider %q", second.Provider)
+	}
+	if second.ID != "bar" {
+		t.Errorf("wrong id %q", second.ID)
+	}
+
+	// sensitive attributes must be redacted, others passed through
+	for _, record := range records {
+		if got := record.Attributes["password"]; got != "(sensitive value)" {
+			t.Errorf("%s: sensitive attribute not redacted: %#v", record.Address, got)
+		}
+		if got := record.Attributes["foo"]; got != "value" {
+			t.Errorf("%s: wrong foo attribute %#v", record.Address, got)
+		}
+	}
+}
+
+func TestStateExport_csv(t *testing.T) {
+	statePath := testStateFile(t, testStateExportState())
+
+	p := testProvider()
+	p.GetSchemaReturn = testStateExportProviderSchema()
+
+	ui := new(cli.MockUi)
+	c := &StateExportCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-format", "csv",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(ui.OutputWriter.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %s", err)
+	}
+
+	if len(rows) != 3 {
+		t.Fatalf("expected header plus 2 rows, got %d rows", len(rows))
+	}
+	header := strings.Join(rows[0], ",")
+	if header != "address,module,mode,type,name,index,provider,id,attributes" {
+		t.Fatalf("wrong header %q", header)
+	}
+
+	root := rows[2]
+	if root[0] != "test_instance.foo" {
+		t.Errorf("wrong address %q", root[0])
+	}
+	if root[7] != "bar" {
+		t.Errorf("wrong id %q", root[7])
+	}
+
+	// the attributes column carries the redacted attributes as JSON
+	var attrs map[string]interface{}
+	if err := json.Unmarshal([]byte(root[8]), &attrs); err != nil {
+		t.Fatalf("attributes column is not valid JSON: %s", err)
+	}
+	if attrs["password"] != "(sensitive value)" {
+		t.Errorf("sensitive attribute not redacted: %#v", attrs["password"])
+	}
+}
+
+func TestStateExport_noSchema(t *testing.T) {
+	statePath := testStateFile(t, testStateExportState())
+
+	// A provider without schema for the resource type: the identity columns
+	// must still be exported, but no attributes, since we can't tell which
+	// are sensitive.
+	p := testProvider()
+	p.GetSchemaReturn = &terraform.ProviderSchema{}
+
+	ui := new(cli.MockUi)
+	c := &StateExportCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	var records []*stateExportRecord
+	if err := json.Unmarshal(ui.OutputWriter.Bytes(), &records); err != nil {
+		t.Fatalf("output is not valid JSON: %s", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	for _, record := range records {
+		if record.Attributes != nil {
+			t.Errorf("%s: attributes exported without a schema: %#v", record.Address, record.Attributes)
+		}
+		if record.ID == "" {
+			t.Errorf("%s: missing id", record.Address)
+		}
+	}
+}
+
+func TestStateExport_badFormat(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := &StateExportCommand{
+		Meta: Meta{
+			Ui: ui,
+		},
+	}
+
+	if code := c.Run([]string{"-format", "xml"}); code != 1 {
+		t.Fatalf("expected failure for unsupported format")
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), `Unsupported format "xml"`) {
+		t.Fatalf("unexpected error output: %s", ui.ErrorWriter.String())
+	}
+}
@@ -0,0 +1,384 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/command/foreignstate"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform/tfdiags"
+)
+
+// StateImportForeignCommand is a Command implementation that imports the
+// resources described by a foreign inventory document, such as a
+// CloudFormation stack resource listing or a Pulumi state export, into the
+// Terraform state.
+type StateImportForeignCommand struct {
+	Meta
+}
+
+func (c *StateImportForeignCommand) Run(args []string) int {
+	// Get the pwd since its our default -config flag value
+	pwd, err := os.Getwd()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error getting pwd: %s", err))
+		return 1
+	}
+
+	var configPath, format, mapPath string
+	var dryRun bool
+	args = c.Meta.process(args)
+
+	cmdFlags := c.Meta.extendedFlagSet("state import-foreign")
+	cmdFlags.StringVar(&format, "format", "", "foreign document format")
+	cmdFlags.StringVar(&mapPath, "map", "", "path to a JSON file of type mapping overrides")
+	cmdFlags.BoolVar(&dryRun, "dry-run", false, "show the import operations without performing them")
+	cmdFlags.StringVar(&c.Meta.statePath, "state", "", "path")
+	cmdFlags.StringVar(&c.Meta.stateOutPath, "state-out", "", "path")
+	cmdFlags.StringVar(&c.Meta.backupPath, "backup", "", "path")
+	cmdFlags.StringVar(&configPath, "config", pwd, "path")
+	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
+	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
+	cmdFlags.BoolVar(&c.Meta.allowMissingConfig, "allow-missing-config", false, "allow missing config")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = cmdFlags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("The state import-foreign command expects one argument: the path to the foreign document.")
+		cmdFlags.Usage()
+		return 1
+	}
+
+	var diags tfdiags.Diagnostics
+
+	translator := foreignstate.Lookup(format)
+	if translator == nil {
+		if format == "" {
+			c.Ui.Error(fmt.Sprintf(
+				"The -format option is required. Supported formats are: %s.",
+				strings.Join(foreignstate.Formats(), ", "),
+			))
+		} else {
+			c.Ui.Error(fmt.Sprintf(
+				"Unsupported format %q. Supported formats are: %s.",
+				format, strings.Join(foreignstate.Formats(), ", "),
+			))
+		}
+		return 1
+	}
+
+	src, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading foreign document: %s", err))
+		return 1
+	}
+
+	resources, err := translator.Translate(src)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error translating foreign document: %s", err))
+		return 1
+	}
+
+	// User-supplied type mappings take precedence over the translator's own
+	// suggestions, so that both unknown foreign types and wrong guesses can
+	// be corrected without changing the translator.
+	if mapPath != "" {
+		mapSrc, err := ioutil.ReadFile(mapPath)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading type mapping file: %s", err))
+			return 1
+		}
+		overrides := make(map[string]string)
+		if err := json.Unmarshal(mapSrc, &overrides); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error parsing type mapping file: %s (expected a JSON object mapping foreign types to Terraform resource types)", err))
+			return 1
+		}
+		for i, r := range resources {
+			if mapped, ok := overrides[r.ForeignType]; ok {
+				resources[i].Type = mapped
+			}
+		}
+	}
+
+	// Report foreign types we can't translate all together, rather than
+	// failing on the first, since fixing them usually means writing one
+	// mapping file covering them all.
+	unmapped := make(map[string]struct{})
+	for _, r := range resources {
+		if r.Type == "" {
+			unmapped[r.ForeignType] = struct{}{}
+		}
+	}
+	if len(unmapped) > 0 {
+		types := make([]string, 0, len(unmapped))
+		for t := range unmapped {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unmapped foreign resource types",
+			fmt.Sprintf(
+				"No Terraform resource type is known for the following foreign types:\n  - %s\n\nUse the -map option to supply a JSON file mapping these to Terraform resource types.",
+				strings.Join(types, "\n  - "),
+			),
+		))
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	targets := c.foreignImportTargets(resources)
+
+	if dryRun {
+		c.Ui.Output("The following import operations would be performed:\n")
+		for _, target := range targets {
+			c.Ui.Output(fmt.Sprintf("  %s <- %s", target.Addr, target.ID))
+		}
+		return 0
+	}
+
+	// Verify that the given addresses point to something that exists in
+	// config, for the same reason the import command does: importing
+	// resources with no configuration produces a state that the next plan
+	// will want to destroy.
+	if !c.Meta.allowMissingConfig {
+		if !c.dirIsConfigPath(configPath) {
+			c.Ui.Error(fmt.Sprintf(
+				"The directory %s does not contain any Terraform configuration files (.tf or .tf.json). To specify a different configuration directory, use the -config=\"...\" command line option, or use -allow-missing-config to import without configuration.",
+				configPath,
+			))
+			return 1
+		}
+		config, configDiags := c.loadConfig(configPath)
+		diags = diags.Append(configDiags)
+		if configDiags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+
+		var missing []string
+		for _, target := range targets {
+			found := false
+			for _, rc := range config.Module.ManagedResources {
+				if rc.Type == target.Addr.Resource.Resource.Type && rc.Name == target.Addr.Resource.Resource.Name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				missing = append(missing, target.Addr.String())
+			}
+		}
+		if len(missing) > 0 {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Resources not found in configuration",
+				fmt.Sprintf(
+					"The following resources are not declared in the configuration:\n  - %s\n\nAdd resource blocks for them before importing, or use -allow-missing-config to import anyway. Resources imported without configuration will be proposed for destruction by the next plan.",
+					strings.Join(missing, "\n  - "),
+				),
+			))
+			c.showDiagnostics(diags)
+			return 1
+		}
+	}
+
+	// Check for user-supplied plugin path
+	if c.pluginPath, err = c.loadPluginPath(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error loading plugin path: %s", err))
+		return 1
+	}
+
+	// Load the backend
+	b, backendDiags := c.Backend(nil)
+	diags = diags.Append(backendDiags)
+	if backendDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// We require a backend.Local to build a context.
+	local, ok := b.(backend.Local)
+	if !ok {
+		c.Ui.Error(ErrUnsupportedLocalOp)
+		return 1
+	}
+
+	// Build the operation
+	opReq := c.Operation(b)
+	opReq.ConfigDir = configPath
+	opReq.ConfigLoader, err = c.initConfigLoader()
+	if err != nil {
+		diags = diags.Append(err)
+		c.showDiagnostics(diags)
+		return 1
+	}
+	{
+		var moreDiags tfdiags.Diagnostics
+		opReq.Variables, moreDiags = c.collectVariableValues()
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+	}
+
+	// Get the context
+	ctx, state, ctxDiags := local.Context(opReq)
+
+	// Creating the context can result in a lock, so ensure we release it
+	defer func() {
+		err := opReq.StateLocker.Unlock(nil)
+		if err != nil {
+			c.Ui.Error(err.Error())
+		}
+	}()
+
+	diags = diags.Append(ctxDiags)
+	if ctxDiags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Perform all of the imports in one operation, so that a failure in any
+	// one of them leaves the state untouched.
+	newState, importDiags := ctx.Import(&terraform.ImportOpts{
+		Targets: targets,
+	})
+	diags = diags.Append(importDiags)
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	// Persist the final state
+	log.Printf("[INFO] Writing state output to: %s", c.Meta.StateOutPath())
+	if err := state.WriteState(newState); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing state file: %s", err))
+		return 1
+	}
+	if err := state.PersistState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error writing state file: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[reset][green]\nImport successful! Imported %d resources.", len(targets),
+	)))
+
+	c.showDiagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+
+	return 0
+}
+
+// foreignImportTargets converts the translated resources into import
+// targets in the root module, making the suggested resource names unique by
+// appending a numeric suffix where the foreign document reuses a logical
+// name across resources of the same type.
+func (c *StateImportForeignCommand) foreignImportTargets(resources []foreignstate.Resource) []*terraform.ImportTarget {
+	used := make(map[string]struct{})
+	targets := make([]*terraform.ImportTarget, 0, len(resources))
+	for _, r := range resources {
+		name := r.Name
+		for i := 2; ; i++ {
+			if _, taken := used[r.Type+"."+name]; !taken {
+				break
+			}
+			name = fmt.Sprintf("%s_%d", r.Name, i)
+		}
+		used[r.Type+"."+name] = struct{}{}
+
+		addr := addrs.Resource{
+			Mode: addrs.ManagedResourceMode,
+			Type: r.Type,
+			Name: name,
+		}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance)
+
+		targets = append(targets, &terraform.ImportTarget{
+			Addr: addr,
+			ID:   r.ID,
+		})
+	}
+	return targets
+}
+
+func (c *StateImportForeignCommand) Help() string {
+	helpText := `
+Usage: terraform state import-foreign [options] PATH
+
+  Import the resources described by a foreign inventory document into the
+  Terraform state, by translating the document into a batch of import
+  operations.
+
+  The document at PATH must be in one of the supported formats:
+
+    cloudformation   The JSON output of the AWS CLI commands
+                     "aws cloudformation describe-stack-resources" or
+                     "aws cloudformation list-stack-resources".
+
+    pulumi           The JSON output of "pulumi stack export".
+
+  Each resource is imported into the root module, named after its logical
+  name in the source document. Translators map foreign resource types to
+  Terraform resource types on a best-effort basis; use the -map option to
+  supply mappings for foreign types the translator doesn't know, or to
+  override ones it gets wrong. Use -dry-run first to review the operations
+  that would be performed.
+
+  As with the import command, the providers for the imported resources must
+  be installed and configured in the importing configuration, and each
+  imported resource should already have a corresponding resource block.
+
+Options:
+
+  -format=name            The format of the foreign document. Required.
+
+  -map=path               Path to a JSON file containing an object that maps
+                          foreign resource types to Terraform resource
+                          types, taking precedence over the translator's
+                          built-in mappings.
+
+  -dry-run                Show the import operations that would be performed
+                          without performing them.
+
+  -config=path            Path to a directory of Terraform configuration files
+                          to use to configure the providers that are used to
+                          perform the imports. Defaults to pwd.
+
+  -allow-missing-config   Allow importing resources that have no resource
+                          blocks in the configuration.
+
+  -lock=true              Lock the state file when locking is supported.
+
+  -lock-timeout=0s        Duration to retry a state lock.
+
+  -state=PATH             Path to the source state file. Defaults to the
+                          configured backend, or "terraform.tfstate"
+
+  -state-out=PATH         Path to the destination state file to write to. If
+                          this isn't specified, the source state file will be
+                          used. This can be a new or existing path.
+
+  -backup=PATH            Path where Terraform should write the backup
+                          state.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateImportForeignCommand) Synopsis() string {
+	return "Import resources described by a foreign inventory document"
+}
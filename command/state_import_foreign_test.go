@@ -0,0 +1,140 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestStateImportForeign_dryRun(t *testing.T) {
+	td := tempDir(t)
+	if err := os.MkdirAll(td, 0755); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+
+	doc := `{
+		"StackResources": [
+			{
+				"LogicalResourceId": "WebServer",
+				"PhysicalResourceId": "i-0123456789abcdef0",
+				"ResourceType": "AWS::EC2::Instance"
+			}
+		]
+	}`
+	docPath := filepath.Join(td, "resources.json")
+	if err := ioutil.WriteFile(docPath, []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := new(cli.MockUi)
+	c := &StateImportForeignCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{
+		"-format=cloudformation",
+		"-dry-run",
+		docPath,
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, "aws_instance.WebServer <- i-0123456789abcdef0") {
+		t.Errorf("missing expected import operation in output:\n%s", output)
+	}
+}
+
+func TestStateImportForeign_unmappedType(t *testing.T) {
+	td := tempDir(t)
+	if err := os.MkdirAll(td, 0755); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+
+	doc := `{
+		"StackResources": [
+			{
+				"LogicalResourceId": "Exotic",
+				"PhysicalResourceId": "something",
+				"ResourceType": "AWS::Example::Widget"
+			}
+		]
+	}`
+	docPath := filepath.Join(td, "resources.json")
+	if err := ioutil.WriteFile(docPath, []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := new(cli.MockUi)
+	c := &StateImportForeignCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{
+		"-format=cloudformation",
+		"-dry-run",
+		docPath,
+	}
+	if code := c.Run(args); code != 1 {
+		t.Fatalf("expected failure for unmapped type, got %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "AWS::Example::Widget") {
+		t.Errorf("error does not mention the unmapped type:\n%s", ui.ErrorWriter.String())
+	}
+
+	// A mapping file resolves the failure.
+	mapPath := filepath.Join(td, "map.json")
+	if err := ioutil.WriteFile(mapPath, []byte(`{"AWS::Example::Widget": "example_widget"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui = new(cli.MockUi)
+	c = &StateImportForeignCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+		},
+	}
+	args = []string{
+		"-format=cloudformation",
+		"-map=" + mapPath,
+		"-dry-run",
+		docPath,
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "example_widget.Exotic <- something") {
+		t.Errorf("missing expected import operation in output:\n%s", ui.OutputWriter.String())
+	}
+}
+
+func TestStateImportForeign_unsupportedFormat(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := &StateImportForeignCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(testProvider()),
+			Ui:               ui,
+		},
+	}
+
+	if code := c.Run([]string{"-format=nope", "whatever.json"}); code != 1 {
+		t.Fatalf("expected failure, got %d", code)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "cloudformation, pulumi") {
+		t.Errorf("error does not list the supported formats:\n%s", ui.ErrorWriter.String())
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/backend/remote-state/inmem"
 	"github.com/hashicorp/terraform/helper/copy"
 	"github.com/hashicorp/terraform/states"
@@ -254,7 +254,7 @@ func TestStatePush_forceRemoteState(t *testing.T) {
 	}
 
 	// put a dummy state in place, so we have something to force
-	b := backend.TestBackendConfig(t, inmem.New(), nil)
+	b := backendtest.TestBackendConfig(t, inmem.New(), nil)
 	sMgr, err := b.StateMgr("test")
 	if err != nil {
 		t.Fatal(err)
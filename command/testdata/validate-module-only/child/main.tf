@@ -0,0 +1,11 @@
+variable "ami" {
+  type = string
+}
+
+resource "test_instance" "foo" {
+  ami = var.ami
+}
+
+output "id" {
+  value = test_instance.foo.ami
+}
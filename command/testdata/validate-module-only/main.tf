@@ -0,0 +1,13 @@
+variable "ami" {
+  type = string
+}
+
+module "child" {
+  source = "./child"
+
+  ami = var.ami
+}
+
+output "child_id" {
+  value = module.child.id
+}
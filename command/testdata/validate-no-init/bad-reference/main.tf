@@ -0,0 +1,3 @@
+resource "example_thing" "foo" {
+  name = var.undeclared
+}
@@ -0,0 +1,15 @@
+variable "name" {
+  type = string
+}
+
+resource "example_thing" "foo" {
+  name = var.name
+
+  settings {
+    enabled = true
+  }
+}
+
+output "id" {
+  value = example_thing.foo.id
+}
@@ -0,0 +1,164 @@
+package command
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/colorstring"
+)
+
+const (
+	// UIThemeEnvVar is the environment variable consulted for the default
+	// color theme when the -ui-theme global option isn't given.
+	UIThemeEnvVar = "TF_UI_THEME"
+
+	// UIASCIIEnvVar is the environment variable that enables the ASCII-only
+	// output mode when set to a true value, as if -ascii were given.
+	UIASCIIEnvVar = "TF_UI_ASCII"
+)
+
+// uiThemeColors returns the colorstring palette for the named theme, or
+// ok=false if the name isn't a known theme.
+//
+// The "dark" theme is the palette Terraform has always used, chosen for
+// readability on dark terminal backgrounds. The "light" theme replaces the
+// bright colors that are hard to read on light backgrounds with their darker
+// counterparts. The "none" theme disables color entirely, equivalent to the
+// -no-color option, and is represented here by a nil palette.
+func uiThemeColors(theme string) (colors map[string]string, ok bool) {
+	switch theme {
+	case "", "dark":
+		return colorstring.DefaultColors, true
+	case "light":
+		return uiLightColors, true
+	case "none":
+		return nil, true
+	default:
+		return nil, false
+	}
+}
+
+// uiLightColors is the "light" theme palette: the default palette with the
+// bright foreground colors remapped to darker codes that remain legible on a
+// light background. Background colors and attributes are unchanged.
+var uiLightColors map[string]string
+
+func init() {
+	uiLightColors = make(map[string]string, len(colorstring.DefaultColors))
+	for name, code := range colorstring.DefaultColors {
+		uiLightColors[name] = code
+	}
+	for name, code := range map[string]string{
+		"yellow":        "35", // dark yellow reads as mustard-on-white; use magenta
+		"light_gray":    "90",
+		"dark_gray":     "90",
+		"light_red":     "31",
+		"light_green":   "32",
+		"light_yellow":  "35",
+		"light_blue":    "34",
+		"light_magenta": "35",
+		"light_cyan":    "36",
+		"white":         "30",
+	} {
+		uiLightColors[name] = code
+	}
+}
+
+// asciiUi is a cli.Ui implementation that transliterates all output to
+// plain ASCII before passing it to the wrapped Ui, for terminals and CI log
+// consoles that can't render other characters. Terraform's own messages are
+// already ASCII, but diagnostics quote configuration source and repeat
+// messages from providers and modules, either of which may contain arbitrary
+// characters.
+type asciiUi struct {
+	Ui cli.Ui
+}
+
+var _ cli.Ui = (*asciiUi)(nil)
+
+func (u *asciiUi) Ask(query string) (string, error) {
+	return u.Ui.Ask(asciiSafe(query))
+}
+
+func (u *asciiUi) AskSecret(query string) (string, error) {
+	return u.Ui.AskSecret(asciiSafe(query))
+}
+
+func (u *asciiUi) Output(message string) {
+	u.Ui.Output(asciiSafe(message))
+}
+
+func (u *asciiUi) Info(message string) {
+	u.Ui.Info(asciiSafe(message))
+}
+
+func (u *asciiUi) Error(message string) {
+	u.Ui.Error(asciiSafe(message))
+}
+
+func (u *asciiUi) Warn(message string) {
+	u.Ui.Warn(asciiSafe(message))
+}
+
+// asciiReplacements maps characters that commonly appear in quoted
+// configuration or provider messages to reasonable ASCII stand-ins. Anything
+// not in this table and not already ASCII becomes a question mark.
+var asciiReplacements = map[rune]string{
+	'\u00a0': " ", // non-breaking space
+	'‘':      "'",
+	'’':      "'",
+	'“':      `"`,
+	'”':      `"`,
+	'–':      "-",
+	'—':      "-",
+	'…':      "...",
+	'•':      "*",
+	'→':      "->",
+	'←':      "<-",
+	'─':      "-",
+	'│':      "|",
+	'╷':      "|",
+	'╵':      "|",
+	'┌':      "+",
+	'┐':      "+",
+	'└':      "+",
+	'┘':      "+",
+	'├':      "+",
+	'┤':      "+",
+	'┼':      "+",
+}
+
+// asciiSafe returns the given string with all non-ASCII characters replaced
+// by ASCII approximations, so that it can safely be written to an output
+// stream that supports only ASCII.
+func asciiSafe(s string) string {
+	// The common case is a string that's already plain ASCII, which we can
+	// return unchanged without allocating.
+	plain := true
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			plain = false
+			break
+		}
+	}
+	if plain {
+		return s
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case r <= unicode.MaxASCII:
+			buf.WriteRune(r)
+		default:
+			if repl, ok := asciiReplacements[r]; ok {
+				buf.WriteString(repl)
+			} else {
+				buf.WriteByte('?')
+			}
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,150 @@
+package command
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/mitchellh/colorstring"
+)
+
+func TestMetaUITheme(t *testing.T) {
+	var m *Meta
+	var args, args2 []string
+
+	// The flag selects the palette and is stripped from the args.
+	m = new(Meta)
+	m.Color = true
+	args = []string{"foo", "-ui-theme=light", "bar"}
+	args2 = []string{"foo", "bar"}
+	args = m.process(args)
+	if !reflect.DeepEqual(args, args2) {
+		t.Fatalf("bad: %#v", args)
+	}
+	if got := m.Colorize().Colors["white"]; got != uiLightColors["white"] {
+		t.Fatalf("wrong palette entry %q; want %q", got, uiLightColors["white"])
+	}
+	if m.Colorize().Disable {
+		t.Fatal("should not be disabled")
+	}
+
+	// The "none" theme disables color entirely, like -no-color.
+	m = new(Meta)
+	m.Color = true
+	args = m.process([]string{"-ui-theme=none"})
+	if len(args) != 0 {
+		t.Fatalf("bad: %#v", args)
+	}
+	if !m.Colorize().Disable {
+		t.Fatal("should be disabled")
+	}
+
+	// An unknown theme falls back to the default palette rather than
+	// failing the command.
+	m = new(Meta)
+	m.Color = true
+	m.process([]string{"-ui-theme=sepia"})
+	if got := m.Colorize().Colors["white"]; got != colorstring.DefaultColors["white"] {
+		t.Fatalf("wrong palette entry %q; want default %q", got, colorstring.DefaultColors["white"])
+	}
+}
+
+func TestMetaUITheme_envVar(t *testing.T) {
+	old := os.Getenv(UIThemeEnvVar)
+	defer os.Setenv(UIThemeEnvVar, old)
+	if err := os.Setenv(UIThemeEnvVar, "light"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	m := new(Meta)
+	m.Color = true
+	m.process(nil)
+	if got := m.Colorize().Colors["white"]; got != uiLightColors["white"] {
+		t.Fatalf("wrong palette entry %q; want %q", got, uiLightColors["white"])
+	}
+
+	// The flag takes precedence over the environment.
+	m = new(Meta)
+	m.Color = true
+	m.process([]string{"-ui-theme=dark"})
+	if got := m.Colorize().Colors["white"]; got != colorstring.DefaultColors["white"] {
+		t.Fatalf("wrong palette entry %q; want %q", got, colorstring.DefaultColors["white"])
+	}
+}
+
+func TestMetaUIASCII(t *testing.T) {
+	old := os.Getenv(UIASCIIEnvVar)
+	defer os.Setenv(UIASCIIEnvVar, old)
+	if err := os.Setenv(UIASCIIEnvVar, ""); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	m := new(Meta)
+	args := m.process([]string{"foo", "-ascii"})
+	if !reflect.DeepEqual(args, []string{"foo"}) {
+		t.Fatalf("bad: %#v", args)
+	}
+	if !m.uiASCII {
+		t.Fatal("ASCII mode should be enabled")
+	}
+
+	m = new(Meta)
+	m.process([]string{"foo"})
+	if m.uiASCII {
+		t.Fatal("ASCII mode should not be enabled")
+	}
+
+	if err := os.Setenv(UIASCIIEnvVar, "1"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	m = new(Meta)
+	m.process(nil)
+	if !m.uiASCII {
+		t.Fatal("ASCII mode should be enabled from the environment")
+	}
+}
+
+func TestAsciiSafe(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"plain ascii is returned as-is", "plain ascii is returned as-is"},
+		{"\x1b[31mcolored\x1b[0m", "\x1b[31mcolored\x1b[0m"},
+		{"smart “quotes” and ‘more’", `smart "quotes" and 'more'`},
+		{"a → b", "a -> b"},
+		{"wait…", "wait..."},
+		{"│ box ─ drawing ┼", "| box - drawing +"},
+		{"café", "caf?"},
+	}
+	for _, test := range tests {
+		if got := asciiSafe(test.input); got != test.want {
+			t.Errorf("asciiSafe(%q) = %q; want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestUIThemeColors(t *testing.T) {
+	if _, ok := uiThemeColors("sepia"); ok {
+		t.Error("unknown theme should not be ok")
+	}
+	if colors, ok := uiThemeColors("none"); !ok || colors != nil {
+		t.Error("the none theme should be a nil palette")
+	}
+	if colors, ok := uiThemeColors(""); !ok || colors["white"] != colorstring.DefaultColors["white"] {
+		t.Error("the empty theme name should select the default palette")
+	}
+	// The light palette must define exactly the same names as the default
+	// palette so that existing color markup keeps working.
+	for name := range colorstring.DefaultColors {
+		if _, ok := uiLightColors[name]; !ok {
+			t.Errorf("light palette is missing %q", name)
+		}
+	}
+	for name := range uiLightColors {
+		if _, ok := colorstring.DefaultColors[name]; !ok {
+			t.Errorf("light palette has extra entry %q", name)
+		}
+	}
+}
@@ -6,8 +6,13 @@ import (
 	"path/filepath"
 	"strings"
 
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
 
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/configs"
+	"github.com/hashicorp/terraform/providers"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/hashicorp/terraform/tfdiags"
 )
@@ -29,9 +34,11 @@ func (c *ValidateCommand) Run(args []string) int {
 	varValues := c.Meta.variableArgs.Alias("-var")
 	varFiles := c.Meta.variableArgs.Alias("-var-file")
 
-	var jsonOutput bool
+	var jsonOutput, noInit, moduleOnly bool
 	cmdFlags := c.Meta.defaultFlagSet("validate")
 	cmdFlags.BoolVar(&jsonOutput, "json", false, "produce JSON output")
+	cmdFlags.BoolVar(&noInit, "no-init", false, "synthesize schema stubs for uninstalled providers")
+	cmdFlags.BoolVar(&moduleOnly, "module-only", false, "validate the directory as an isolated module")
 	cmdFlags.Var(varValues, "var", "variables")
 	cmdFlags.Var(varFiles, "var-file", "variable file")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
@@ -76,16 +83,27 @@ func (c *ValidateCommand) Run(args []string) int {
 		return c.showResults(diags, jsonOutput)
 	}
 
-	validateDiags := c.validate(dir)
+	validateDiags := c.validate(dir, noInit, moduleOnly)
 	diags = diags.Append(validateDiags)
 
 	return c.showResults(diags, jsonOutput)
 }
 
-func (c *ValidateCommand) validate(dir string) tfdiags.Diagnostics {
+func (c *ValidateCommand) validate(dir string, noInit, moduleOnly bool) tfdiags.Diagnostics {
 	var diags tfdiags.Diagnostics
 
-	cfg, cfgDiags := c.loadConfig(dir)
+	var cfg *configs.Config
+	var cfgDiags tfdiags.Diagnostics
+	if moduleOnly {
+		// Module-only mode loads the configuration straight from disk with
+		// no help from "terraform init", so providers can't have been
+		// installed either and we always substitute stubs for any that are
+		// missing.
+		noInit = true
+		cfg, cfgDiags = loadIsolatedModule(dir)
+	} else {
+		cfg, cfgDiags = c.loadConfig(dir)
+	}
 	diags = diags.Append(cfgDiags)
 
 	if diags.HasErrors() {
@@ -114,6 +132,21 @@ func (c *ValidateCommand) validate(dir string) tfdiags.Diagnostics {
 	opts.Config = cfg
 	opts.Variables = varValues
 
+	if noInit {
+		// For any provider required by the configuration but not installed
+		// locally, substitute a stub whose schema is synthesized from the
+		// configuration itself, so that validation of everything else can
+		// still proceed without running "terraform init" first.
+		if opts.Providers == nil {
+			opts.Providers = make(map[addrs.Provider]providers.Factory)
+		}
+		for _, provider := range cfg.ProviderTypes() {
+			if _, available := opts.Providers[provider]; !available {
+				opts.Providers[provider] = stubProviderFactory(provider, cfg)
+			}
+		}
+	}
+
 	tfCtx, ctxDiags := terraform.NewContext(opts)
 	diags = diags.Append(ctxDiags)
 	if ctxDiags.HasErrors() {
@@ -125,6 +158,48 @@ func (c *ValidateCommand) validate(dir string) tfdiags.Diagnostics {
 	return diags
 }
 
+// loadIsolatedModule loads the module in the given directory, and any local
+// child modules it calls, directly from disk without consulting the module
+// manifest that "terraform init" would normally produce. This allows a
+// module repository to be validated on its own, without a root configuration
+// or backend, but it means that module calls with non-local source addresses
+// cannot be resolved and are reported as errors.
+func loadIsolatedModule(dir string) (*configs.Config, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	parser := configs.NewParser(nil)
+	mod, modDiags := parser.LoadConfigDir(dir)
+	diags = diags.Append(modDiags)
+	if mod == nil || modDiags.HasErrors() {
+		return nil, diags
+	}
+
+	cfg, buildDiags := configs.BuildConfig(mod, configs.ModuleWalkerFunc(
+		func(req *configs.ModuleRequest) (*configs.Module, *version.Version, hcl.Diagnostics) {
+			source := req.SourceAddr
+			if !strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+				return nil, nil, hcl.Diagnostics{
+					{
+						Severity: hcl.DiagError,
+						Summary:  "Non-local module source",
+						Detail: fmt.Sprintf(
+							"Module %q has source %q, which cannot be resolved when validating a module in isolation. Run \"terraform init\" to install it and validate without -module-only.",
+							req.Name, source,
+						),
+						Subject: req.SourceAddrRange.Ptr(),
+					},
+				}
+			}
+
+			childDir := filepath.Join(req.Parent.Module.SourceDir, source)
+			child, childDiags := parser.LoadConfigDir(childDir)
+			return child, nil, childDiags
+		},
+	))
+	diags = diags.Append(buildDiags)
+	return cfg, diags
+}
+
 func (c *ValidateCommand) showResults(diags tfdiags.Diagnostics, jsonOutput bool) int {
 	switch {
 	case jsonOutput:
@@ -265,6 +340,20 @@ Options:
                Always disables color.
 
   -no-color    If specified, output won't contain any color.
+
+  -no-init     Validate without requiring providers to be installed, by
+               synthesizing a permissive schema stub for each missing
+               provider from its usage in the configuration. Argument names
+               and value types for missing providers are not checked in this
+               mode.
+
+  -module-only Validate the directory as a shared module rather than as a
+               root configuration, without requiring "terraform init" to
+               have run first. Required input variables are populated with
+               unknown values, local child modules are loaded directly from
+               disk, and -no-init is implied. Module calls with non-local
+               sources cannot be resolved in this mode and are reported as
+               errors.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -0,0 +1,343 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/configs"
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+)
+
+// stubProviderFactory returns a providers.Factory for a provider that is not
+// installed locally, synthesizing a permissive schema from the way the
+// provider and its resources are used in the given configuration.
+//
+// This supports "terraform validate -no-init": the synthesized schemas accept
+// every argument that appears in the configuration with a dynamic type, so
+// syntax and reference validation can still run while argument-name and type
+// checking for the missing provider is effectively skipped.
+func stubProviderFactory(provider addrs.Provider, cfg *configs.Config) providers.Factory {
+	return func() (providers.Interface, error) {
+		return &stubProvider{schema: stubProviderSchema(provider, cfg)}, nil
+	}
+}
+
+// stubProviderSchema synthesizes a provider schema covering every resource
+// type, data source, and provider configuration of the given provider that
+// appears anywhere in the given configuration.
+func stubProviderSchema(provider addrs.Provider, cfg *configs.Config) providers.GetSchemaResponse {
+	resp := providers.GetSchemaResponse{
+		Provider:      providers.Schema{Block: &configschema.Block{}},
+		ResourceTypes: map[string]providers.Schema{},
+		DataSources:   map[string]providers.Schema{},
+	}
+
+	cfg.DeepEach(func(c *configs.Config) {
+		mod := c.Module
+		if mod == nil {
+			return
+		}
+		for _, pc := range mod.ProviderConfigs {
+			if mod.ProviderForLocalConfig(pc.Addr()) != provider {
+				continue
+			}
+			resp.Provider = providers.Schema{
+				Block: mergeStubBlocks(resp.Provider.Block, stubSchemaForBody(pc.Config)),
+			}
+		}
+		for _, r := range mod.ManagedResources {
+			if r.Provider != provider {
+				continue
+			}
+			existing := resp.ResourceTypes[r.Type].Block
+			resp.ResourceTypes[r.Type] = providers.Schema{
+				Block: mergeStubBlocks(existing, stubSchemaForBody(r.Config)),
+			}
+		}
+		for _, r := range mod.DataResources {
+			if r.Provider != provider {
+				continue
+			}
+			existing := resp.DataSources[r.Type].Block
+			resp.DataSources[r.Type] = providers.Schema{
+				Block: mergeStubBlocks(existing, stubSchemaForBody(r.Config)),
+			}
+		}
+
+		// Configurations commonly refer to attributes that the provider
+		// computes, like "id", which therefore never appear in any resource
+		// block. We learn those attribute names from references elsewhere in
+		// the same module so that such expressions can still type-check.
+		for _, traversal := range stubModuleTraversals(mod) {
+			ref, refDiags := addrs.ParseRef(traversal)
+			if refDiags.HasErrors() || ref == nil {
+				continue
+			}
+			var rAddr addrs.Resource
+			switch sub := ref.Subject.(type) {
+			case addrs.Resource:
+				rAddr = sub
+			case addrs.ResourceInstance:
+				rAddr = sub.Resource
+			default:
+				continue
+			}
+			if r := mod.ResourceByAddr(rAddr); r == nil || r.Provider != provider {
+				continue
+			}
+			if len(ref.Remaining) == 0 {
+				continue
+			}
+			attrStep, ok := ref.Remaining[0].(hcl.TraverseAttr)
+			if !ok {
+				continue
+			}
+			var schemas map[string]providers.Schema
+			switch rAddr.Mode {
+			case addrs.ManagedResourceMode:
+				schemas = resp.ResourceTypes
+			case addrs.DataResourceMode:
+				schemas = resp.DataSources
+			default:
+				continue
+			}
+			schema, exists := schemas[rAddr.Type]
+			if !exists {
+				continue
+			}
+			if _, exists := schema.Block.Attributes[attrStep.Name]; exists {
+				continue
+			}
+			if _, exists := schema.Block.BlockTypes[attrStep.Name]; exists {
+				continue
+			}
+			schema.Block.Attributes[attrStep.Name] = &configschema.Attribute{
+				Type:     cty.DynamicPseudoType,
+				Computed: true,
+			}
+		}
+	})
+
+	return resp
+}
+
+// stubSchemaForBody constructs a permissive schema block describing the
+// given configuration body: every attribute is optional with a dynamic type,
+// and every nested block is accepted any number of times.
+func stubSchemaForBody(body hcl.Body) *configschema.Block {
+	ret := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{},
+		BlockTypes: map[string]*configschema.NestedBlock{},
+	}
+
+	switch b := body.(type) {
+	case *hclsyntax.Body:
+		for name := range b.Attributes {
+			ret.Attributes[name] = &configschema.Attribute{
+				Type:     cty.DynamicPseudoType,
+				Optional: true,
+			}
+		}
+		for _, block := range b.Blocks {
+			nested := stubSchemaForBody(block.Body)
+			if existing, exists := ret.BlockTypes[block.Type]; exists {
+				nested = mergeStubBlocks(&existing.Block, nested)
+			}
+			ret.BlockTypes[block.Type] = &configschema.NestedBlock{
+				Block:   *nested,
+				Nesting: configschema.NestingList,
+			}
+		}
+	default:
+		// For other body implementations (e.g. JSON syntax) we can't cheaply
+		// distinguish attributes from blocks, so we'll just accept all of
+		// the attribute names we can see.
+		attrs, _ := body.JustAttributes()
+		for name := range attrs {
+			ret.Attributes[name] = &configschema.Attribute{
+				Type:     cty.DynamicPseudoType,
+				Optional: true,
+			}
+		}
+	}
+
+	return ret
+}
+
+// stubModuleTraversals returns all of the variable traversals that appear in
+// expressions throughout the given module.
+func stubModuleTraversals(mod *configs.Module) []hcl.Traversal {
+	var ret []hcl.Traversal
+	body := func(b hcl.Body) {
+		ret = append(ret, stubBodyTraversals(b)...)
+	}
+	expr := func(e hcl.Expression) {
+		if e != nil {
+			ret = append(ret, e.Variables()...)
+		}
+	}
+
+	for _, pc := range mod.ProviderConfigs {
+		body(pc.Config)
+	}
+	for _, r := range mod.ManagedResources {
+		body(r.Config)
+		expr(r.Count)
+		expr(r.ForEach)
+	}
+	for _, r := range mod.DataResources {
+		body(r.Config)
+		expr(r.Count)
+		expr(r.ForEach)
+	}
+	for _, l := range mod.Locals {
+		expr(l.Expr)
+	}
+	for _, o := range mod.Outputs {
+		expr(o.Expr)
+	}
+	for _, mc := range mod.ModuleCalls {
+		body(mc.Config)
+		expr(mc.Count)
+		expr(mc.ForEach)
+	}
+	return ret
+}
+
+// stubBodyTraversals returns the variable traversals used by all of the
+// expressions in the given body, as best we can tell from outside the schema.
+func stubBodyTraversals(body hcl.Body) []hcl.Traversal {
+	var ret []hcl.Traversal
+	switch b := body.(type) {
+	case *hclsyntax.Body:
+		for _, attr := range b.Attributes {
+			ret = append(ret, attr.Expr.Variables()...)
+		}
+		for _, block := range b.Blocks {
+			ret = append(ret, stubBodyTraversals(block.Body)...)
+		}
+	default:
+		attrs, _ := body.JustAttributes()
+		for _, attr := range attrs {
+			ret = append(ret, attr.Expr.Variables()...)
+		}
+	}
+	return ret
+}
+
+// mergeStubBlocks combines two synthesized schema blocks, since the same
+// resource type may appear several times in a configuration with different
+// subsets of arguments set.
+func mergeStubBlocks(base, overlay *configschema.Block) *configschema.Block {
+	if base == nil {
+		return overlay
+	}
+	if base.Attributes == nil {
+		base.Attributes = map[string]*configschema.Attribute{}
+	}
+	if base.BlockTypes == nil {
+		base.BlockTypes = map[string]*configschema.NestedBlock{}
+	}
+	for name, attr := range overlay.Attributes {
+		base.Attributes[name] = attr
+	}
+	for name, blockType := range overlay.BlockTypes {
+		if existing, exists := base.BlockTypes[name]; exists {
+			merged := mergeStubBlocks(&existing.Block, &blockType.Block)
+			base.BlockTypes[name] = &configschema.NestedBlock{
+				Block:   *merged,
+				Nesting: configschema.NestingList,
+			}
+			continue
+		}
+		base.BlockTypes[name] = blockType
+	}
+	return base
+}
+
+// stubProvider is a minimal providers.Interface implementation used only
+// during "terraform validate -no-init". It reports the synthesized schema
+// and accepts any configuration; all operational methods fail, since a stub
+// can never be used for plan or apply.
+type stubProvider struct {
+	schema providers.GetSchemaResponse
+}
+
+var _ providers.Interface = (*stubProvider)(nil)
+
+func (p *stubProvider) GetSchema() providers.GetSchemaResponse {
+	return p.schema
+}
+
+func (p *stubProvider) PrepareProviderConfig(req providers.PrepareProviderConfigRequest) providers.PrepareProviderConfigResponse {
+	return providers.PrepareProviderConfigResponse{
+		PreparedConfig: req.Config,
+	}
+}
+
+func (p *stubProvider) ValidateResourceTypeConfig(req providers.ValidateResourceTypeConfigRequest) providers.ValidateResourceTypeConfigResponse {
+	return providers.ValidateResourceTypeConfigResponse{}
+}
+
+func (p *stubProvider) ValidateDataSourceConfig(req providers.ValidateDataSourceConfigRequest) providers.ValidateDataSourceConfigResponse {
+	return providers.ValidateDataSourceConfigResponse{}
+}
+
+func (p *stubProvider) UpgradeResourceState(req providers.UpgradeResourceStateRequest) providers.UpgradeResourceStateResponse {
+	var resp providers.UpgradeResourceStateResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.errUnsupported("UpgradeResourceState"))
+	return resp
+}
+
+func (p *stubProvider) Configure(req providers.ConfigureRequest) providers.ConfigureResponse {
+	var resp providers.ConfigureResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.errUnsupported("Configure"))
+	return resp
+}
+
+func (p *stubProvider) Stop() error {
+	return nil
+}
+
+func (p *stubProvider) ReadResource(req providers.ReadResourceRequest) providers.ReadResourceResponse {
+	var resp providers.ReadResourceResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.errUnsupported("ReadResource"))
+	return resp
+}
+
+func (p *stubProvider) PlanResourceChange(req providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+	var resp providers.PlanResourceChangeResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.errUnsupported("PlanResourceChange"))
+	return resp
+}
+
+func (p *stubProvider) ApplyResourceChange(req providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+	var resp providers.ApplyResourceChangeResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.errUnsupported("ApplyResourceChange"))
+	return resp
+}
+
+func (p *stubProvider) ImportResourceState(req providers.ImportResourceStateRequest) providers.ImportResourceStateResponse {
+	var resp providers.ImportResourceStateResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.errUnsupported("ImportResourceState"))
+	return resp
+}
+
+func (p *stubProvider) ReadDataSource(req providers.ReadDataSourceRequest) providers.ReadDataSourceResponse {
+	var resp providers.ReadDataSourceResponse
+	resp.Diagnostics = resp.Diagnostics.Append(p.errUnsupported("ReadDataSource"))
+	return resp
+}
+
+func (p *stubProvider) Close() error {
+	return nil
+}
+
+func (p *stubProvider) errUnsupported(operation string) error {
+	return fmt.Errorf("%s is not supported by the validate-only provider stub; run \"terraform init\" to install the real provider", operation)
+}
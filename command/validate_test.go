@@ -198,3 +198,58 @@ func TestMissingDefinedVar(t *testing.T) {
 		t.Fatalf("Should have passed: %d\n\n%s", code, ui.ErrorWriter.String())
 	}
 }
+
+func TestValidateMissingProviderShouldFail(t *testing.T) {
+	// The fixture uses a provider that is not installed, so a normal
+	// validate should fail.
+	ui, code := setupTest("validate-no-init")
+	if code != 1 {
+		t.Fatalf("Should have failed: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+}
+
+func TestValidateNoInit(t *testing.T) {
+	// With -no-init, the missing provider's schema is synthesized from the
+	// configuration itself, so validation of everything else can still run.
+	ui, code := setupTest("validate-no-init", "-no-init")
+	if code != 0 {
+		t.Fatalf("Should have passed: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+}
+
+func TestValidateNoInitStillCatchesErrors(t *testing.T) {
+	// -no-init must not suppress errors that don't depend on provider
+	// schemas, such as references to undeclared variables.
+	ui, code := setupTest("validate-no-init/bad-reference", "-no-init")
+	if code != 1 {
+		t.Fatalf("Should have failed: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	wantError := "Reference to undeclared input variable"
+	if !strings.Contains(ui.ErrorWriter.String(), wantError) {
+		t.Fatalf("Missing error string %q\n\n'%s'", wantError, ui.ErrorWriter.String())
+	}
+}
+
+func TestValidateModuleOnly(t *testing.T) {
+	// With -module-only, the directory and its local child modules are
+	// loaded directly from disk, with no module manifest from "terraform
+	// init" and no root configuration or backend required.
+	ui, code := setupTest("validate-module-only", "-module-only")
+	if code != 0 {
+		t.Fatalf("Should have passed: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+}
+
+func TestValidateModuleOnlyNonLocalSource(t *testing.T) {
+	// Module calls with non-local sources can't be resolved without
+	// "terraform init", so -module-only must report them as errors rather
+	// than silently skipping them.
+	ui, code := setupTest("validate-module-only-remote", "-module-only")
+	if code != 1 {
+		t.Fatalf("Should have failed: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	wantError := "Non-local module source"
+	if !strings.Contains(ui.ErrorWriter.String(), wantError) {
+		t.Fatalf("Missing error string %q\n\n'%s'", wantError, ui.ErrorWriter.String())
+	}
+}
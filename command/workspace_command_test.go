@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform/addrs"
 	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/backend/backendtest"
 	"github.com/hashicorp/terraform/backend/local"
 	"github.com/hashicorp/terraform/backend/remote-state/inmem"
 	"github.com/hashicorp/terraform/helper/copy"
@@ -271,7 +272,7 @@ func TestWorkspace_createWithState(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	b := backend.TestBackendConfig(t, inmem.New(), nil)
+	b := backendtest.TestBackendConfig(t, inmem.New(), nil)
 	sMgr, err := b.StateMgr(workspace)
 	if err != nil {
 		t.Fatal(err)
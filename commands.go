@@ -375,6 +375,18 @@ func initCommands(config *cliconfig.Config, services *disco.Disco, providerSrc g
 			}, nil
 		},
 
+		"state import-foreign": func() (cli.Command, error) {
+			return &command.StateImportForeignCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"state export": func() (cli.Command, error) {
+			return &command.StateExportCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"state replace-provider": func() (cli.Command, error) {
 			return &command.StateReplaceProviderCommand{
 				StateMeta: command.StateMeta{
@@ -22,6 +22,11 @@ func BuildConfig(root *Module, walker ModuleWalker) (*Config, hcl.Diagnostics) {
 	}
 	cfg.Root = cfg // Root module is self-referential.
 	cfg.Children, diags = buildChildModules(cfg, walker)
+
+	// Now that the full tree is assembled we can check how each module uses
+	// its children, which is how deprecated variables and outputs surface.
+	diags = append(diags, checkDeprecatedUsage(cfg)...)
+
 	return cfg, diags
 }
 
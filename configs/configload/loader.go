@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-svchost/disco"
 	"github.com/hashicorp/terraform/configs"
 	"github.com/hashicorp/terraform/registry"
@@ -23,6 +24,11 @@ type Loader struct {
 	// modules is used to install and locate descendent modules that are
 	// referenced (directly or indirectly) from the root module.
 	modules moduleMgr
+
+	// prefetchDiags stashes diagnostics produced while concurrently
+	// prefetching module directories in LoadConfig, keyed by directory,
+	// for replay when the module walk first requests each directory.
+	prefetchDiags map[string]hcl.Diagnostics
 }
 
 // Config is used with NewLoader to specify configuration arguments for the
@@ -2,6 +2,7 @@ package configload
 
 import (
 	"fmt"
+	"sync"
 
 	version "github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
@@ -24,12 +25,66 @@ func (l *Loader) LoadConfig(rootDir string) (*configs.Config, hcl.Diagnostics) {
 		return nil, diags
 	}
 
+	l.prefetchModuleDirs()
+
 	cfg, cDiags := configs.BuildConfig(rootMod, configs.ModuleWalkerFunc(l.moduleWalkerLoad))
 	diags = append(diags, cDiags...)
 
 	return cfg, diags
 }
 
+// moduleDirPrefetchWorkers is the maximum number of module directories loaded
+// concurrently by prefetchModuleDirs. Like file loading in the configs
+// package, this is largely I/O-bound work.
+const moduleDirPrefetchWorkers = 8
+
+// prefetchModuleDirs loads every module directory recorded in the module
+// manifest with a bounded pool of workers, warming the parser's memoized
+// file cache so that the serial module walk in BuildConfig doesn't need to
+// read or parse anything itself. This is where most of the time goes when
+// loading a configuration with many module instances from a slow filesystem.
+//
+// The parser reports each file's parse diagnostics only on its first load,
+// so the diagnostics gathered here are stashed per directory and replayed
+// by moduleWalkerLoad when the walk first requests that directory; they
+// can't just be returned directly because at this point we don't yet know
+// which module calls (if any) the walk will resolve to each directory.
+func (l *Loader) prefetchModuleDirs() {
+	if l.prefetchDiags == nil {
+		l.prefetchDiags = make(map[string]hcl.Diagnostics)
+	}
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		sem = make(chan struct{}, moduleDirPrefetchWorkers)
+	)
+	for key, record := range l.modules.manifest {
+		if key == "" {
+			continue // the root module is loaded directly by our caller
+		}
+		if _, done := l.prefetchDiags[record.Dir]; done {
+			continue // already prefetched by an earlier load
+		}
+		l.prefetchDiags[record.Dir] = nil
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(dir string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			_, mDiags := l.parser.LoadConfigDir(dir)
+			if len(mDiags) > 0 {
+				mu.Lock()
+				l.prefetchDiags[dir] = mDiags
+				mu.Unlock()
+			}
+		}(record.Dir)
+	}
+	wg.Wait()
+}
+
 // moduleWalkerLoad is a configs.ModuleWalkerFunc for loading modules that
 // are presumed to have already been installed. A different function
 // (moduleWalkerInstall) is used for installation.
@@ -85,6 +140,13 @@ func (l *Loader) moduleWalkerLoad(req *configs.ModuleRequest) (*configs.Module,
 	}
 
 	mod, mDiags := l.parser.LoadConfigDir(record.Dir)
+	if pre, exists := l.prefetchDiags[record.Dir]; exists && len(pre) > 0 {
+		// The prefetch pass got to this directory's files first, so the
+		// parse diagnostics were reported there rather than in the load
+		// above; replay them for the first module call using the directory.
+		mDiags = append(pre, mDiags...)
+		l.prefetchDiags[record.Dir] = nil
+	}
 	diags = append(diags, mDiags...)
 	if mod == nil {
 		// nil specifically indicates that the directory does not exist or
@@ -80,3 +80,34 @@ func TestLoaderLoadConfig_addVersion(t *testing.T) {
 		t.Fatalf("wrong error\ngot:\n%s\n\nwant: containing %q", got, want)
 	}
 }
+
+func TestLoaderLoadConfig_invalidModuleSyntax(t *testing.T) {
+	// A syntax error in an installed module must be reported exactly once,
+	// even though the module directory is both prefetched concurrently and
+	// then requested by two separate module calls during the walk.
+	fixtureDir := filepath.Clean("testdata/invalid-module-syntax")
+	loader, err := NewLoader(&Config{
+		ModulesDir: filepath.Join(fixtureDir, ".terraform/modules"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error from NewLoader: %s", err)
+	}
+
+	_, diags := loader.LoadConfig(fixtureDir)
+	if !diags.HasErrors() {
+		t.Fatalf("success; want error")
+	}
+
+	// One parse of the broken file produces several distinct diagnostics,
+	// but each of them must appear only once even though the directory is
+	// loaded for two module calls.
+	seen := map[string]int{}
+	for _, diag := range diags {
+		seen[diag.Summary+" at "+diag.Subject.String()]++
+	}
+	for key, count := range seen {
+		if count != 1 {
+			t.Errorf("diagnostic %q reported %d times; want 1", key, count)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+module "child_a" {
+  source  = "example.com/foo/bar_a/baz"
+  version = ">= 1.0.0"
+}
+
+module "child_a_again" {
+  source  = "example.com/foo/bar_a/baz"
+  version = ">= 1.0.0"
+}
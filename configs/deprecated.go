@@ -0,0 +1,158 @@
+package configs
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/hashicorp/terraform/addrs"
+)
+
+// checkDeprecatedUsage inspects how each module in the given configuration
+// tree uses the variables and outputs of its child modules, and returns
+// warning diagnostics for any use of a variable or output that the child
+// module has marked with the "deprecated" argument.
+//
+// The warnings are attributed to the location in the calling module where
+// the deprecated item is used, so that the caller knows what to update.
+func checkDeprecatedUsage(cfg *Config) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	cfg.DeepEach(func(c *Config) {
+		diags = append(diags, checkModuleDeprecatedUsage(c)...)
+	})
+	return diags
+}
+
+func checkModuleDeprecatedUsage(c *Config) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	mod := c.Module
+
+	// Warn when a module call sets an argument that the child module has
+	// declared as a deprecated variable.
+	for name, call := range mod.ModuleCalls {
+		child, exists := c.Children[name]
+		if !exists {
+			continue
+		}
+		for varName, v := range child.Module.Variables {
+			if v.Deprecated == "" {
+				continue
+			}
+			schema := &hcl.BodySchema{
+				Attributes: []hcl.AttributeSchema{{Name: varName}},
+			}
+			content, _, _ := call.Config.PartialContent(schema)
+			if attr, set := content.Attributes[varName]; set {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  "Deprecated variable used",
+					Detail:   fmt.Sprintf("The variable %q of module %q is deprecated: %s", varName, name, v.Deprecated),
+					Subject:  attr.Range.Ptr(),
+				})
+			}
+		}
+	}
+
+	// Warn when any expression in this module refers to an output that a
+	// child module has declared as deprecated.
+	for _, traversal := range moduleExprTraversals(mod) {
+		ref, refDiags := addrs.ParseRef(traversal)
+		if refDiags.HasErrors() || ref == nil {
+			continue
+		}
+		callOut, ok := ref.Subject.(addrs.AbsModuleCallOutput)
+		if !ok {
+			continue
+		}
+		callName := callOut.Call.Call.Name
+		child, exists := c.Children[callName]
+		if !exists {
+			continue
+		}
+		o, exists := child.Module.Outputs[callOut.Name]
+		if !exists || o.Deprecated == "" {
+			continue
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  "Deprecated output used",
+			Detail:   fmt.Sprintf("The output %q of module %q is deprecated: %s", callOut.Name, callName, o.Deprecated),
+			Subject:  traversal.SourceRange().Ptr(),
+		})
+	}
+
+	// Much of the above is driven by map iteration, so we'll sort by source
+	// location to make the ordering of the warnings deterministic.
+	sort.SliceStable(diags, func(i, j int) bool {
+		iRng, jRng := diags[i].Subject, diags[j].Subject
+		if iRng.Filename != jRng.Filename {
+			return iRng.Filename < jRng.Filename
+		}
+		return iRng.Start.Byte < jRng.Start.Byte
+	})
+
+	return diags
+}
+
+// moduleExprTraversals returns all of the variable traversals that appear in
+// expressions throughout the given module.
+func moduleExprTraversals(mod *Module) []hcl.Traversal {
+	var ret []hcl.Traversal
+	body := func(b hcl.Body) {
+		ret = append(ret, bodyExprTraversals(b)...)
+	}
+	expr := func(e hcl.Expression) {
+		if e != nil {
+			ret = append(ret, e.Variables()...)
+		}
+	}
+
+	for _, pc := range mod.ProviderConfigs {
+		body(pc.Config)
+	}
+	for _, r := range mod.ManagedResources {
+		body(r.Config)
+		expr(r.Count)
+		expr(r.ForEach)
+	}
+	for _, r := range mod.DataResources {
+		body(r.Config)
+		expr(r.Count)
+		expr(r.ForEach)
+	}
+	for _, l := range mod.Locals {
+		expr(l.Expr)
+	}
+	for _, o := range mod.Outputs {
+		expr(o.Expr)
+	}
+	for _, mc := range mod.ModuleCalls {
+		body(mc.Config)
+		expr(mc.Count)
+		expr(mc.ForEach)
+	}
+	return ret
+}
+
+// bodyExprTraversals returns the variable traversals used by all of the
+// expressions in the given body, as best we can tell without a schema.
+func bodyExprTraversals(body hcl.Body) []hcl.Traversal {
+	var ret []hcl.Traversal
+	switch b := body.(type) {
+	case *hclsyntax.Body:
+		for _, attr := range b.Attributes {
+			ret = append(ret, attr.Expr.Variables()...)
+		}
+		for _, block := range b.Blocks {
+			ret = append(ret, bodyExprTraversals(block.Body)...)
+		}
+	default:
+		attrs, _ := body.JustAttributes()
+		for _, attr := range attrs {
+			ret = append(ret, attr.Expr.Variables()...)
+		}
+	}
+	return ret
+}
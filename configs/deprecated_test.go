@@ -0,0 +1,52 @@
+package configs
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestCheckDeprecatedUsage(t *testing.T) {
+	parser := NewParser(nil)
+	mod, diags := parser.LoadConfigDir("testdata/deprecated")
+	assertNoDiagnostics(t, diags)
+	if mod == nil {
+		t.Fatal("got nil root module; want non-nil")
+	}
+
+	_, diags = BuildConfig(mod, ModuleWalkerFunc(
+		func(req *ModuleRequest) (*Module, *version.Version, hcl.Diagnostics) {
+			// Source addresses are treated as paths relative to the fixture
+			// directory, as in TestBuildConfig.
+			sourcePath := filepath.Join("testdata/deprecated", req.SourceAddr)
+			mod, diags := parser.LoadConfigDir(sourcePath)
+			return mod, nil, diags
+		},
+	))
+	if diags.HasErrors() {
+		t.Fatalf("unexpected error diagnostics: %s", diags.Error())
+	}
+
+	if got, want := len(diags), 2; got != want {
+		t.Fatalf("wrong number of warnings %d; want %d\n%s", got, want, diags.Error())
+	}
+
+	wantDetails := []string{
+		`The variable "old_name" of module "child" is deprecated: Use new_name instead.`,
+		`The output "old_id" of module "child" is deprecated: Use id instead.`,
+	}
+	for i, want := range wantDetails {
+		if diags[i].Severity != hcl.DiagWarning {
+			t.Errorf("diagnostic %d is not a warning", i)
+		}
+		if got := diags[i].Detail; got != want {
+			t.Errorf("wrong detail for diagnostic %d\ngot:  %s\nwant: %s", i, got, want)
+		}
+		if subj := diags[i].Subject; subj == nil || !strings.HasSuffix(subj.Filename, "root.tf") {
+			t.Errorf("diagnostic %d is not attributed to the calling module: %#v", i, subj)
+		}
+	}
+}
@@ -28,6 +28,9 @@ type Module struct {
 
 	ActiveExperiments experiments.Set
 
+	MaxDestroy *PlanLimit
+	MaxChanges *PlanLimit
+
 	Backend              *Backend
 	ProviderConfigs      map[string]*Provider
 	ProviderRequirements *RequiredProviders
@@ -60,6 +63,9 @@ type File struct {
 
 	ActiveExperiments experiments.Set
 
+	MaxDestroy []*PlanLimit
+	MaxChanges []*PlanLimit
+
 	Backends          []*Backend
 	ProviderConfigs   []*Provider
 	ProviderMetas     []*ProviderMeta
@@ -175,6 +181,32 @@ func (m *Module) appendFile(file *File) hcl.Diagnostics {
 
 	m.ActiveExperiments = experiments.SetUnion(m.ActiveExperiments, file.ActiveExperiments)
 
+	for _, l := range file.MaxDestroy {
+		if m.MaxDestroy != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate max_destroy setting",
+				Detail:   fmt.Sprintf("A module may have only one max_destroy setting. It was previously set at %s.", m.MaxDestroy.DeclRange),
+				Subject:  &l.DeclRange,
+			})
+			continue
+		}
+		m.MaxDestroy = l
+	}
+
+	for _, l := range file.MaxChanges {
+		if m.MaxChanges != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate max_changes setting",
+				Detail:   fmt.Sprintf("A module may have only one max_changes setting. It was previously set at %s.", m.MaxChanges.DeclRange),
+				Subject:  &l.DeclRange,
+			})
+			continue
+		}
+		m.MaxChanges = l
+	}
+
 	for _, b := range file.Backends {
 		if m.Backend != nil {
 			diags = append(diags, &hcl.Diagnostic{
@@ -330,6 +362,16 @@ func (m *Module) mergeFile(file *File) hcl.Diagnostics {
 		}
 	}
 
+	if len(file.MaxDestroy) != 0 {
+		// An override file clobbers any existing setting; only the last
+		// one in the file is retained, matching other singleton settings.
+		m.MaxDestroy = file.MaxDestroy[len(file.MaxDestroy)-1]
+	}
+
+	if len(file.MaxChanges) != 0 {
+		m.MaxChanges = file.MaxChanges[len(file.MaxChanges)-1]
+	}
+
 	if len(file.Backends) != 0 {
 		switch len(file.Backends) {
 		case 1:
@@ -26,7 +26,13 @@ type Variable struct {
 	ParsingMode VariableParsingMode
 	Validations []*VariableValidation
 
+	// Deprecated, if non-empty, marks this variable as deprecated and gives
+	// a message for the module author to explain what callers should do
+	// instead. Callers that set a deprecated variable get a warning.
+	Deprecated string
+
 	DescriptionSet bool
+	DeprecatedSet  bool
 
 	DeclRange hcl.Range
 }
@@ -87,6 +93,21 @@ func decodeVariableBlock(block *hcl.Block, override bool) (*Variable, hcl.Diagno
 		v.DescriptionSet = true
 	}
 
+	if attr, exists := content.Attributes["deprecated"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &v.Deprecated)
+		diags = append(diags, valDiags...)
+		v.DeprecatedSet = true
+
+		if !valDiags.HasErrors() && v.Deprecated == "" {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid deprecation message",
+				Detail:   "The deprecated argument requires a non-empty message telling callers what to use instead.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
 	if attr, exists := content.Attributes["type"]; exists {
 		ty, parseMode, tyDiags := decodeVariableType(attr.Expr)
 		diags = append(diags, tyDiags...)
@@ -426,8 +447,14 @@ type Output struct {
 	DependsOn   []hcl.Traversal
 	Sensitive   bool
 
+	// Deprecated, if non-empty, marks this output as deprecated and gives
+	// a message for the module author to explain what callers should do
+	// instead. Callers that refer to a deprecated output get a warning.
+	Deprecated string
+
 	DescriptionSet bool
 	SensitiveSet   bool
+	DeprecatedSet  bool
 
 	DeclRange hcl.Range
 }
@@ -460,6 +487,21 @@ func decodeOutputBlock(block *hcl.Block, override bool) (*Output, hcl.Diagnostic
 		o.DescriptionSet = true
 	}
 
+	if attr, exists := content.Attributes["deprecated"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &o.Deprecated)
+		diags = append(diags, valDiags...)
+		o.DeprecatedSet = true
+
+		if !valDiags.HasErrors() && o.Deprecated == "" {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid deprecation message",
+				Detail:   "The deprecated argument requires a non-empty message telling callers what to use instead.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
 	if attr, exists := content.Attributes["value"]; exists {
 		o.Expr = attr.Expr
 	}
@@ -534,6 +576,9 @@ var variableBlockSchema = &hcl.BodySchema{
 		{
 			Name: "type",
 		},
+		{
+			Name: "deprecated",
+		},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{
@@ -570,5 +615,8 @@ var outputBlockSchema = &hcl.BodySchema{
 		{
 			Name: "sensitive",
 		},
+		{
+			Name: "deprecated",
+		},
 	},
 }
@@ -3,9 +3,12 @@ package configs
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
 	"github.com/spf13/afero"
 )
 
@@ -17,6 +20,17 @@ import (
 type Parser struct {
 	fs afero.Afero
 	p  *hclparse.Parser
+
+	// mu guards p and known, since a parser may be shared between several
+	// goroutines that are loading configuration files concurrently.
+	//
+	// known memoizes the result of parsing each file by path, so that a file
+	// shared between several module directories (or a directory loaded once
+	// per module call that refers to it) is read and parsed only once.
+	// Matching the behavior of the underlying hclparse cache, parse
+	// diagnostics for a file are returned only on the first load.
+	mu    sync.Mutex
+	known map[string]*hcl.File
 }
 
 // NewParser creates and returns a new Parser that reads files from the given
@@ -28,8 +42,9 @@ func NewParser(fs afero.Fs) *Parser {
 	}
 
 	return &Parser{
-		fs: afero.Afero{Fs: fs},
-		p:  hclparse.NewParser(),
+		fs:    afero.Afero{Fs: fs},
+		p:     hclparse.NewParser(),
+		known: make(map[string]*hcl.File),
 	}
 }
 
@@ -47,6 +62,13 @@ func NewParser(fs afero.Fs) *Parser {
 // The file will be parsed using the HCL native syntax unless the filename
 // ends with ".json", in which case the HCL JSON syntax will be used.
 func (p *Parser) LoadHCLFile(path string) (hcl.Body, hcl.Diagnostics) {
+	p.mu.Lock()
+	if existing := p.known[path]; existing != nil {
+		p.mu.Unlock()
+		return existing.Body, nil
+	}
+	p.mu.Unlock()
+
 	src, err := p.fs.ReadFile(path)
 
 	if err != nil {
@@ -59,14 +81,29 @@ func (p *Parser) LoadHCLFile(path string) (hcl.Body, hcl.Diagnostics) {
 		}
 	}
 
+	// We parse outside of the lock so that several files can be parsed
+	// concurrently by separate goroutines sharing this parser.
 	var file *hcl.File
 	var diags hcl.Diagnostics
 	switch {
 	case strings.HasSuffix(path, ".json"):
-		file, diags = p.p.ParseJSON(src, path)
+		file, diags = json.Parse(src, path)
 	default:
-		file, diags = p.p.ParseHCL(src, path)
+		file, diags = hclsyntax.ParseConfig(src, path, hcl.Pos{Byte: 0, Line: 1, Column: 1})
+	}
+
+	p.mu.Lock()
+	if existing := p.known[path]; existing != nil {
+		// Another goroutine raced us to this file; keep its result (and
+		// discard our duplicate diagnostics) so each file is reported once.
+		p.mu.Unlock()
+		return existing.Body, nil
+	}
+	if file != nil {
+		p.known[path] = file
+		p.p.AddFile(path, file)
 	}
+	p.mu.Unlock()
 
 	// If the returned file or body is nil, then we'll return a non-nil empty
 	// body so we'll meet our contract that nil means an error reading the file.
@@ -81,6 +118,8 @@ func (p *Parser) LoadHCLFile(path string) (hcl.Body, hcl.Diagnostics) {
 // have been loaded through this parser, with source filenames (as requested
 // when each file was opened) as the keys.
 func (p *Parser) Sources() map[string][]byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.p.Sources()
 }
 
@@ -93,6 +132,8 @@ func (p *Parser) Sources() map[string][]byte {
 func (p *Parser) ForceFileSource(filename string, src []byte) {
 	// We'll make a synthetic hcl.File here just so we can reuse the
 	// existing cache.
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.p.AddFile(filename, &hcl.File{
 		Body:  hcl.EmptyBody(),
 		Bytes: src,
@@ -62,6 +62,22 @@ func (p *Parser) loadConfigFile(path string, override bool) (*File, hcl.Diagnost
 			// here because sniffCoreVersionRequirements and
 			// sniffActiveExperiments already dealt with those above.
 
+			if attr, exists := content.Attributes["max_destroy"]; exists {
+				limit, limitDiags := decodePlanLimitAttr(attr)
+				diags = append(diags, limitDiags...)
+				if limit != nil {
+					file.MaxDestroy = append(file.MaxDestroy, limit)
+				}
+			}
+
+			if attr, exists := content.Attributes["max_changes"]; exists {
+				limit, limitDiags := decodePlanLimitAttr(attr)
+				diags = append(diags, limitDiags...)
+				if limit != nil {
+					file.MaxChanges = append(file.MaxChanges, limit)
+				}
+			}
+
 			for _, innerBlock := range content.Blocks {
 				switch innerBlock.Type {
 
@@ -244,6 +260,8 @@ var terraformBlockSchema = &hcl.BodySchema{
 	Attributes: []hcl.AttributeSchema{
 		{Name: "required_version"},
 		{Name: "experiments"},
+		{Name: "max_destroy"},
+		{Name: "max_changes"},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/hcl/v2"
 )
@@ -52,7 +53,7 @@ func (p *Parser) LoadConfigDir(path string) (*Module, hcl.Diagnostics) {
 //
 // If the given directory does not exist or cannot be read, error diagnostics
 // are returned. If errors are returned, the resulting lists may be incomplete.
-func (p Parser) ConfigDirFiles(dir string) (primary, override []string, diags hcl.Diagnostics) {
+func (p *Parser) ConfigDirFiles(dir string) (primary, override []string, diags hcl.Diagnostics) {
 	return p.dirFiles(dir)
 }
 
@@ -64,19 +65,53 @@ func (p *Parser) IsConfigDir(path string) bool {
 	return (len(primaryPaths) + len(overridePaths)) > 0
 }
 
+// configFileLoadWorkers is the maximum number of files loaded concurrently
+// by loadFiles. Loading is largely I/O-bound, so a few workers keep a
+// network filesystem busy without swamping the local machine.
+const configFileLoadWorkers = 8
+
 func (p *Parser) loadFiles(paths []string, override bool) ([]*File, hcl.Diagnostics) {
 	var files []*File
 	var diags hcl.Diagnostics
 
-	for _, path := range paths {
-		var f *File
-		var fDiags hcl.Diagnostics
-		if override {
-			f, fDiags = p.LoadConfigFileOverride(path)
-		} else {
-			f, fDiags = p.LoadConfigFile(path)
-		}
-		diags = append(diags, fDiags...)
+	// We load the files concurrently with a bounded number of workers,
+	// gathering results by index so that the files and their diagnostics
+	// retain the same deterministic order a sequential load would produce.
+	type result struct {
+		f     *File
+		diags hcl.Diagnostics
+	}
+	results := make([]result, len(paths))
+
+	workers := configFileLoadWorkers
+	if len(paths) < workers {
+		workers = len(paths)
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indices {
+				if override {
+					results[idx].f, results[idx].diags = p.LoadConfigFileOverride(paths[idx])
+				} else {
+					results[idx].f, results[idx].diags = p.LoadConfigFile(paths[idx])
+				}
+			}
+		}()
+	}
+	for idx := range paths {
+		indices <- idx
+	}
+	close(indices)
+	wg.Wait()
+
+	for _, res := range results {
+		f := res.f
+		diags = append(diags, res.diags...)
 		if f != nil {
 			files = append(files, f)
 		}
@@ -0,0 +1,61 @@
+package configs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// PlanLimit represents one of the optional plan guardrail settings in a
+// "terraform" block, such as max_destroy or max_changes, which constrain
+// how many resource changes a single plan is allowed to contain.
+type PlanLimit struct {
+	Value int
+
+	DeclRange hcl.Range
+}
+
+// decodePlanLimitAttr decodes one of the plan guardrail attributes from a
+// "terraform" block, returning nil and error diagnostics if the given value
+// is not a non-negative whole number.
+func decodePlanLimitAttr(attr *hcl.Attribute) (*PlanLimit, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	val, valDiags := attr.Expr.Value(nil)
+	diags = append(diags, valDiags...)
+	if valDiags.HasErrors() {
+		return nil, diags
+	}
+
+	val, err := convert.Convert(val, cty.Number)
+	if err == nil && (val.IsNull() || !val.IsKnown()) {
+		err = fmt.Errorf("value must be known and non-null")
+	}
+	var value int
+	if err == nil {
+		bf := val.AsBigFloat()
+		if !bf.IsInt() {
+			err = fmt.Errorf("value must be a whole number")
+		} else if v, _ := bf.Int64(); v < 0 {
+			err = fmt.Errorf("value must not be negative")
+		} else {
+			value = int(v)
+		}
+	}
+	if err != nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("Invalid %s value", attr.Name),
+			Detail:   fmt.Sprintf("The %s argument requires a non-negative whole number: %s.", attr.Name, err),
+			Subject:  attr.Expr.Range().Ptr(),
+		})
+		return nil, diags
+	}
+
+	return &PlanLimit{
+		Value:     value,
+		DeclRange: attr.Range,
+	}, diags
+}
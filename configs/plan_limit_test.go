@@ -0,0 +1,48 @@
+package configs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParserLoadConfigFilePlanLimits(t *testing.T) {
+	parser := testParser(map[string]string{
+		"limits.tf": `
+terraform {
+  max_destroy = 10
+  max_changes = 200
+}
+`,
+	})
+
+	file, diags := parser.LoadConfigFile("limits.tf")
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	if got := len(file.MaxDestroy); got != 1 {
+		t.Fatalf("wrong number of max_destroy settings %d; want 1", got)
+	}
+	if got, want := file.MaxDestroy[0].Value, 10; got != want {
+		t.Errorf("wrong max_destroy value %d; want %d", got, want)
+	}
+	if got := len(file.MaxChanges); got != 1 {
+		t.Fatalf("wrong number of max_changes settings %d; want 1", got)
+	}
+	if got, want := file.MaxChanges[0].Value, 200; got != want {
+		t.Errorf("wrong max_changes value %d; want %d", got, want)
+	}
+}
+
+// At most one max_destroy and one max_changes setting per module is permitted.
+func TestModule_plan_limits_multiple(t *testing.T) {
+	_, diags := testModuleFromDir("testdata/invalid-modules/multiple-plan-limits")
+	if !diags.HasErrors() {
+		t.Fatal("module should have error diags, but does not")
+	}
+
+	want := `Duplicate max_destroy setting`
+	if got := diags.Error(); !strings.Contains(got, want) {
+		t.Fatalf("expected error to contain %q\nerror was:\n%s", want, got)
+	}
+}
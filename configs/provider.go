@@ -20,6 +20,14 @@ type Provider struct {
 	Alias      string
 	AliasRange *hcl.Range // nil if no alias set
 
+	// ForEach, if non-nil, declares one provider configuration per element
+	// of the given map or set of strings, in place of a single aliased
+	// configuration. Each instance is addressed by its key as if the key
+	// were an alias, e.g. aws["west"], and the configuration body may refer
+	// to each.key and each.value. ForEach and Alias are mutually exclusive.
+	ForEach      hcl.Expression
+	ForEachRange *hcl.Range // nil if no for_each set
+
 	Version VersionConstraint
 
 	Config hcl.Body
@@ -61,6 +69,20 @@ func decodeProviderBlock(block *hcl.Block) (*Provider, hcl.Diagnostics) {
 		}
 	}
 
+	if attr, exists := content.Attributes["for_each"]; exists {
+		provider.ForEach = attr.Expr
+		provider.ForEachRange = attr.Expr.Range().Ptr()
+
+		if provider.AliasRange != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid combination of \"alias\" and \"for_each\"",
+				Detail:   "A provider block with for_each declares one configuration per key, each addressed by its key in place of an alias, so the alias argument cannot also be set.",
+				Subject:  attr.NameRange.Ptr(),
+			})
+		}
+	}
+
 	if attr, exists := content.Attributes["version"]; exists {
 		var versionDiags hcl.Diagnostics
 		provider.Version, versionDiags = decodeVersionConstraint(attr)
@@ -68,7 +90,7 @@ func decodeProviderBlock(block *hcl.Block) (*Provider, hcl.Diagnostics) {
 	}
 
 	// Reserved attribute names
-	for _, name := range []string{"count", "depends_on", "for_each", "source"} {
+	for _, name := range []string{"count", "depends_on", "source"} {
 		if attr, exists := content.Attributes[name]; exists {
 			diags = append(diags, &hcl.Diagnostic{
 				Severity: hcl.DiagError,
@@ -195,10 +217,13 @@ var providerBlockSchema = &hcl.BodySchema{
 			Name: "version",
 		},
 
+		{
+			Name: "for_each",
+		},
+
 		// Attribute names reserved for future expansion.
 		{Name: "count"},
 		{Name: "depends_on"},
-		{Name: "for_each"},
 		{Name: "source"},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/hashicorp/terraform/addrs"
 )
@@ -19,6 +20,12 @@ type Resource struct {
 	Count   hcl.Expression
 	ForEach hcl.Expression
 
+	// Description is an optional human-readable annotation for the resource,
+	// declared in its "lifecycle" block and shown alongside any planned
+	// changes to it so that reviewers have context about its significance.
+	// It is not used for any evaluation.
+	Description string
+
 	ProviderConfigRef *ProviderConfigRef
 	Provider          addrs.Provider
 
@@ -176,6 +183,11 @@ func decodeResourceBlock(block *hcl.Block) (*Resource, hcl.Diagnostics) {
 				r.Managed.PreventDestroySet = true
 			}
 
+			if attr, exists := lcContent.Attributes["description"]; exists {
+				valDiags := gohcl.DecodeExpression(attr.Expr, nil, &r.Description)
+				diags = append(diags, valDiags...)
+			}
+
 			if attr, exists := lcContent.Attributes["ignore_changes"]; exists {
 
 				// ignore_changes can either be a list of relative traversals
@@ -424,19 +436,34 @@ func decodeProviderConfigRef(expr hcl.Expression, argName string) (*ProviderConf
 	}
 
 	if len(traversal) > 1 {
-		aliasStep, ok := traversal[1].(hcl.TraverseAttr)
-		if !ok {
+		switch aliasStep := traversal[1].(type) {
+		case hcl.TraverseAttr:
+			ret.Alias = aliasStep.Name
+			ret.AliasRange = aliasStep.SourceRange().Ptr()
+		case hcl.TraverseIndex:
+			// An index refers to one instance of a provider block that uses
+			// for_each, with the key standing in for an alias. Only constant
+			// string keys can be resolved statically here.
+			if aliasStep.Key.Type() != cty.String || aliasStep.Key.IsNull() {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid provider configuration reference",
+					Detail:   "A provider configuration instance key must be a constant string.",
+					Subject:  aliasStep.SourceRange().Ptr(),
+				})
+				return ret, diags
+			}
+			ret.Alias = aliasStep.Key.AsString()
+			ret.AliasRange = aliasStep.SourceRange().Ptr()
+		default:
 			diags = append(diags, &hcl.Diagnostic{
 				Severity: hcl.DiagError,
 				Summary:  "Invalid provider configuration reference",
-				Detail:   "Provider name must either stand alone or be followed by a period and then a configuration alias.",
+				Detail:   "Provider name must either stand alone or be followed by a period and then a configuration alias, or by an instance key in brackets.",
 				Subject:  traversal[1].SourceRange().Ptr(),
 			})
 			return ret, diags
 		}
-
-		ret.Alias = aliasStep.Name
-		ret.AliasRange = aliasStep.SourceRange().Ptr()
 	}
 
 	return ret, diags
@@ -508,5 +535,8 @@ var resourceLifecycleBlockSchema = &hcl.BodySchema{
 		{
 			Name: "ignore_changes",
 		},
+		{
+			Name: "description",
+		},
 	},
 }
@@ -0,0 +1,19 @@
+variable "old_name" {
+  type       = string
+  default    = null
+  deprecated = "Use new_name instead."
+}
+
+variable "new_name" {
+  type    = string
+  default = null
+}
+
+output "old_id" {
+  value      = "a"
+  deprecated = "Use id instead."
+}
+
+output "id" {
+  value = "a"
+}
@@ -0,0 +1,14 @@
+module "child" {
+  source = "child"
+
+  old_name = "foo"
+  new_name = "bar"
+}
+
+output "old_result" {
+  value = module.child.old_id
+}
+
+output "new_result" {
+  value = module.child.id
+}
@@ -0,0 +1,3 @@
+terraform {
+  max_changes = -1
+}
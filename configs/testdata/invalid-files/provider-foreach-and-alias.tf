@@ -0,0 +1,4 @@
+provider "test" {
+  alias    = "foo"
+  for_each = { a = 1, b = 2 }
+}
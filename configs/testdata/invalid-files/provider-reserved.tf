@@ -9,7 +9,6 @@ provider "test" {
   # These are all reserved and should generate errors.
   count      = 3
   depends_on = ["foo.bar"]
-  for_each   = ["a", "b"]
   source     = "foo.example.com/baz/bar"
   lifecycle {}
   locals {}
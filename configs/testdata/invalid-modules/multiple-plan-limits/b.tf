@@ -0,0 +1,3 @@
+terraform {
+  max_destroy = 20
+}
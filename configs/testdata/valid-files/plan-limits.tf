@@ -0,0 +1,4 @@
+terraform {
+  max_destroy = 10
+  max_changes = 200
+}
@@ -0,0 +1,8 @@
+provider "test" {
+  for_each = {
+    east = "us-east-1"
+    west = "us-west-2"
+  }
+
+  region = each.value
+}
@@ -384,6 +384,14 @@ type TestStep struct {
 	ImportStateVerify       bool
 	ImportStateVerifyIgnore []string
 
+	// PostApplyPlanChecks is an optional set of checks to run against the
+	// JSON representation of the follow-up plan created after applying this
+	// step, in the same format produced by "terraform show -json" for a
+	// saved plan file. Use TestCheckPlanEmpty and
+	// TestCheckPlanResourceChange to make structural assertions about that
+	// plan without resorting to string matching on rendered output.
+	PostApplyPlanChecks []PlanJSONCheck
+
 	// provider s is used internally to maintain a reference to the
 	// underlying providers during the tests
 	providers map[string]terraform.ResourceProvider
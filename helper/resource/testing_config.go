@@ -119,6 +119,22 @@ func testStep(opts terraform.ContextOpts, state *terraform.State, step TestStep)
 	if p, stepDiags = ctx.Plan(); stepDiags.HasErrors() {
 		return state, newOperationError("follow-up plan", stepDiags)
 	}
+
+	// Run any structural checks against the JSON representation of the
+	// follow-up plan before the general empty-plan check below, so that a
+	// more precise assertion failure is reported first.
+	if len(step.PostApplyPlanChecks) > 0 {
+		planJSON, err := planJSONBytes(ctx, p, newState)
+		if err != nil {
+			return state, fmt.Errorf("Error marshaling follow-up plan as JSON: %s", err)
+		}
+		for _, check := range step.PostApplyPlanChecks {
+			if err := check(planJSON); err != nil {
+				return state, fmt.Errorf("Post-apply plan check failed: %s", err)
+			}
+		}
+	}
+
 	if !p.Changes.Empty() {
 		if step.ExpectNonEmptyPlan {
 			log.Printf("[INFO] Got non-empty plan, as expected:\n\n%s", legacyPlanComparisonString(newState, p.Changes))
@@ -11,9 +11,11 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 
 	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/configs/hcl2shim"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/states"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // testStepImportState runs an import state test step
@@ -206,6 +208,26 @@ func testStepImportState(
 				}
 			}
 
+			// If we have a schema then we compare the two attribute maps as
+			// cty values rather than as flatmap strings, so that encoding
+			// details that don't change the effective value -- most notably
+			// the hashes used to index set elements, which routinely differ
+			// between import and refresh -- don't cause a spurious mismatch.
+			if rsrcSchema != nil {
+				actualV, expectedV, err := importStateVerifyCtyValues(rsrcSchema, actual, expected)
+				if err == nil {
+					if !actualV.RawEquals(expectedV) {
+						return state, fmt.Errorf(
+							"ImportStateVerify attributes not equivalent.\n\nactual:\n%#v\n\nexpected:\n%#v",
+							actualV, expectedV)
+					}
+					continue
+				}
+				// If either attribute map doesn't decode against the schema
+				// then we fall back on the flatmap comparison below.
+				log.Printf("[WARN] Test: ImportStateVerify falling back to flatmap comparison for %s: %s", r.Type, err)
+			}
+
 			if !reflect.DeepEqual(actual, expected) {
 				// Determine only the different attributes
 				for k, v := range expected {
@@ -228,3 +250,21 @@ func testStepImportState(
 	// Return the old state (non-imported) so we don't change anything.
 	return state, nil
 }
+
+// importStateVerifyCtyValues decodes two flatmap attribute maps into cty
+// values of the given resource type's implied type, for semantic comparison
+// during ImportStateVerify. An error from either decode means the map
+// doesn't conform to the schema, in which case the caller should fall back
+// on comparing the raw flatmap attributes.
+func importStateVerifyCtyValues(rsrcSchema *schema.Resource, actual, expected map[string]string) (cty.Value, cty.Value, error) {
+	ty := rsrcSchema.CoreConfigSchema().ImpliedType()
+	actualV, err := hcl2shim.HCL2ValueFromFlatmap(actual, ty)
+	if err != nil {
+		return cty.NilVal, cty.NilVal, err
+	}
+	expectedV, err := hcl2shim.HCL2ValueFromFlatmap(expected, ty)
+	if err != nil {
+		return cty.NilVal, cty.NilVal, err
+	}
+	return actualV, expectedV, nil
+}
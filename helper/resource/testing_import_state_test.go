@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -515,3 +516,53 @@ func TestTest_importStateIdFuncFail(t *testing.T) {
 		t.Fatalf("test should fail")
 	}
 }
+
+func TestImportStateVerifyCtyValues(t *testing.T) {
+	rsrc := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tag": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+
+	// The set element hashes differ between the two maps, as they routinely
+	// do between an imported state and a refreshed one, but the effective
+	// values are the same.
+	actual := map[string]string{
+		"name":       "foo",
+		"tag.#":      "2",
+		"tag.123456": "a",
+		"tag.654321": "b",
+	}
+	expected := map[string]string{
+		"name":    "foo",
+		"tag.#":   "2",
+		"tag.111": "b",
+		"tag.222": "a",
+	}
+
+	actualV, expectedV, err := importStateVerifyCtyValues(rsrc, actual, expected)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !actualV.RawEquals(expectedV) {
+		t.Errorf("values not equal\nactual:   %#v\nexpected: %#v", actualV, expectedV)
+	}
+
+	// A genuine difference in value must still be detected.
+	expected["name"] = "bar"
+	actualV, expectedV, err = importStateVerifyCtyValues(rsrc, actual, expected)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if actualV.RawEquals(expectedV) {
+		t.Error("values equal; want mismatch on name")
+	}
+}
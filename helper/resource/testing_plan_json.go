@@ -0,0 +1,110 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/command/jsonplan"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/states/statefile"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// PlanJSONCheck is the signature of a check run against the JSON
+// representation of a plan, in the same format produced by
+// "terraform show -json" for a saved plan file.
+//
+// The raw JSON is passed so that callers can unmarshal whatever subset of
+// the structure they care about, but most tests should be able to use the
+// TestCheckPlanEmpty and TestCheckPlanResourceChange helpers rather than
+// decoding the document themselves.
+type PlanJSONCheck func(planJSON []byte) error
+
+// planJSONSummary is the subset of the JSON plan format that the built-in
+// checks need to decode. The full format is defined by command/jsonplan.
+type planJSONSummary struct {
+	ResourceChanges []planJSONResourceChange `json:"resource_changes"`
+}
+
+type planJSONResourceChange struct {
+	Address string `json:"address"`
+	Change  struct {
+		Actions []string `json:"actions"`
+	} `json:"change"`
+}
+
+// TestCheckPlanEmpty returns a PlanJSONCheck that fails if the plan contains
+// any resource change whose actions are other than "no-op", listing the
+// offending addresses and their planned actions in the error message.
+func TestCheckPlanEmpty() PlanJSONCheck {
+	return func(planJSON []byte) error {
+		var summary planJSONSummary
+		if err := json.Unmarshal(planJSON, &summary); err != nil {
+			return fmt.Errorf("failed to decode JSON plan: %s", err)
+		}
+
+		var changed []string
+		for _, rc := range summary.ResourceChanges {
+			if len(rc.Change.Actions) == 1 && rc.Change.Actions[0] == "no-op" {
+				continue
+			}
+			changed = append(changed, fmt.Sprintf("%s: %s", rc.Address, strings.Join(rc.Change.Actions, ", ")))
+		}
+		if len(changed) > 0 {
+			return fmt.Errorf("plan is not empty:\n  %s", strings.Join(changed, "\n  "))
+		}
+		return nil
+	}
+}
+
+// TestCheckPlanResourceChange returns a PlanJSONCheck that fails unless the
+// plan contains a change for the resource instance with the given address
+// whose actions are exactly those given, in order. Actions use the names
+// from the JSON plan format, such as "create", "update", "delete", and
+// "no-op"; a replacement appears as either ["delete", "create"] or
+// ["create", "delete"] depending on the ordering the provider requested.
+//
+// A step using this to assert a non-empty follow-up plan must also set
+// ExpectNonEmptyPlan, since otherwise the step fails on the general
+// empty-plan check before the JSON checks can report anything more precise.
+func TestCheckPlanResourceChange(address string, actions ...string) PlanJSONCheck {
+	return func(planJSON []byte) error {
+		var summary planJSONSummary
+		if err := json.Unmarshal(planJSON, &summary); err != nil {
+			return fmt.Errorf("failed to decode JSON plan: %s", err)
+		}
+
+		for _, rc := range summary.ResourceChanges {
+			if rc.Address != address {
+				continue
+			}
+			if len(rc.Change.Actions) != len(actions) {
+				return fmt.Errorf(
+					"wrong actions for %s\ngot:  %s\nwant: %s",
+					address, strings.Join(rc.Change.Actions, ", "), strings.Join(actions, ", "),
+				)
+			}
+			for i := range actions {
+				if rc.Change.Actions[i] != actions[i] {
+					return fmt.Errorf(
+						"wrong actions for %s\ngot:  %s\nwant: %s",
+						address, strings.Join(rc.Change.Actions, ", "), strings.Join(actions, ", "),
+					)
+				}
+			}
+			return nil
+		}
+		return fmt.Errorf("plan has no change for %s", address)
+	}
+}
+
+// planJSONBytes produces the JSON representation of the given plan, using
+// the same marshaling as "terraform show -json" so that checks written
+// against test steps see the same structure as checks written against real
+// plan output.
+func planJSONBytes(ctx *terraform.Context, p *plans.Plan, priorState *states.State) ([]byte, error) {
+	sf := statefile.New(priorState, "", 0)
+	return jsonplan.Marshal(ctx.Config(), p, sf, ctx.Schemas())
+}
@@ -0,0 +1,77 @@
+package resource
+
+import (
+	"strings"
+	"testing"
+)
+
+const testPlanJSONEmpty = `{
+	"format_version": "0.1",
+	"resource_changes": [
+		{
+			"address": "test_instance.foo",
+			"change": {"actions": ["no-op"]}
+		}
+	]
+}`
+
+const testPlanJSONChanges = `{
+	"format_version": "0.1",
+	"resource_changes": [
+		{
+			"address": "test_instance.foo",
+			"change": {"actions": ["update"]}
+		},
+		{
+			"address": "test_instance.bar",
+			"change": {"actions": ["delete", "create"]}
+		}
+	]
+}`
+
+func TestTestCheckPlanEmpty(t *testing.T) {
+	if err := TestCheckPlanEmpty()([]byte(testPlanJSONEmpty)); err != nil {
+		t.Errorf("unexpected error for empty plan: %s", err)
+	}
+
+	err := TestCheckPlanEmpty()([]byte(testPlanJSONChanges))
+	if err == nil {
+		t.Fatal("no error for non-empty plan")
+	}
+	if !strings.Contains(err.Error(), "test_instance.foo: update") {
+		t.Errorf("error does not mention the changed resource:\n%s", err)
+	}
+	if !strings.Contains(err.Error(), "test_instance.bar: delete, create") {
+		t.Errorf("error does not mention the replaced resource:\n%s", err)
+	}
+}
+
+func TestTestCheckPlanResourceChange(t *testing.T) {
+	plan := []byte(testPlanJSONChanges)
+
+	if err := TestCheckPlanResourceChange("test_instance.foo", "update")(plan); err != nil {
+		t.Errorf("unexpected error for matching update: %s", err)
+	}
+	if err := TestCheckPlanResourceChange("test_instance.bar", "delete", "create")(plan); err != nil {
+		t.Errorf("unexpected error for matching replace: %s", err)
+	}
+
+	if err := TestCheckPlanResourceChange("test_instance.foo", "delete")(plan); err == nil {
+		t.Error("no error for wrong action")
+	}
+	if err := TestCheckPlanResourceChange("test_instance.bar", "create", "delete")(plan); err == nil {
+		t.Error("no error for wrong action order")
+	}
+	if err := TestCheckPlanResourceChange("test_instance.baz", "create")(plan); err == nil {
+		t.Error("no error for absent resource")
+	}
+}
+
+func TestTestCheckPlanInvalidJSON(t *testing.T) {
+	if err := TestCheckPlanEmpty()([]byte("{")); err == nil {
+		t.Error("TestCheckPlanEmpty: no error for invalid JSON")
+	}
+	if err := TestCheckPlanResourceChange("test_instance.foo", "no-op")([]byte("{")); err == nil {
+		t.Error("TestCheckPlanResourceChange: no error for invalid JSON")
+	}
+}
@@ -0,0 +1,93 @@
+package schema
+
+import (
+	"github.com/zclconf/go-cty/cty"
+)
+
+// SensitiveMark is the cty mark applied by MarkSensitiveValues to values
+// that the schema declares as sensitive.
+const SensitiveMark = "sensitive"
+
+// MarkSensitiveValues returns a copy of the given value, which must conform
+// to the implied type of the resource's schema, with the values of all
+// attributes that the schema flags as Sensitive carrying the cty mark
+// SensitiveMark, including attributes nested inside blocks.
+//
+// Terraform Core does not yet propagate cty marks across the provider wire
+// protocol, so this is useful only to callers that run the SDK in the same
+// process and want to redact sensitive values when rendering them, such as
+// in plan output.
+func (r *Resource) MarkSensitiveValues(v cty.Value) cty.Value {
+	return schemaMap(r.Schema).markSensitiveValues(v)
+}
+
+func (m schemaMap) markSensitiveValues(v cty.Value) cty.Value {
+	if v.IsNull() || !v.IsKnown() {
+		return v
+	}
+
+	vals := v.AsValueMap()
+	if len(vals) == 0 {
+		return v
+	}
+	for name, schema := range m {
+		if av, ok := vals[name]; ok {
+			vals[name] = schema.markSensitiveValue(av)
+		}
+	}
+	return cty.ObjectVal(vals)
+}
+
+func (s *Schema) markSensitiveValue(v cty.Value) cty.Value {
+	if s.Sensitive {
+		return v.Mark(SensitiveMark)
+	}
+
+	nested, ok := s.Elem.(*Resource)
+	if !ok {
+		// A non-Resource element can't declare any of its own attributes
+		// as sensitive, so there is nothing more to do.
+		return v
+	}
+	if v.IsNull() || !v.IsKnown() {
+		return v
+	}
+	nm := schemaMap(nested.Schema)
+
+	switch s.Type {
+	case TypeList:
+		if v.LengthInt() == 0 {
+			return v
+		}
+		elems := v.AsValueSlice()
+		for i, ev := range elems {
+			elems[i] = nm.markSensitiveValues(ev)
+		}
+		return cty.ListVal(elems)
+	case TypeSet:
+		// cty sets cannot contain marked values, so if any nested attribute
+		// is sensitive then the mark is promoted to the whole set.
+		if nm.hasSensitive() {
+			return v.Mark(SensitiveMark)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// hasSensitive returns true if any of the schemas in the map, or in any
+// nested resource within them, is flagged as Sensitive.
+func (m schemaMap) hasSensitive() bool {
+	for _, s := range m {
+		if s.Sensitive {
+			return true
+		}
+		if nested, ok := s.Elem.(*Resource); ok {
+			if schemaMap(nested.Schema).hasSensitive() {
+				return true
+			}
+		}
+	}
+	return false
+}
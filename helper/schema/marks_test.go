@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestResourceMarkSensitiveValues(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"name": {
+				Type:     TypeString,
+				Optional: true,
+			},
+			"password": {
+				Type:      TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"network": {
+				Type:     TypeList,
+				Optional: true,
+				Elem: &Resource{
+					Schema: map[string]*Schema{
+						"cidr": {
+							Type:     TypeString,
+							Optional: true,
+						},
+						"secret_key": {
+							Type:      TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+			"credential": {
+				Type:     TypeSet,
+				Optional: true,
+				Elem: &Resource{
+					Schema: map[string]*Schema{
+						"token": {
+							Type:      TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	v := cty.ObjectVal(map[string]cty.Value{
+		"name":     cty.StringVal("foo"),
+		"password": cty.StringVal("top-secret"),
+		"network": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"cidr":       cty.StringVal("10.0.0.0/8"),
+				"secret_key": cty.StringVal("s3cr3t"),
+			}),
+		}),
+		"credential": cty.SetVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"token": cty.StringVal("abc123"),
+			}),
+		}),
+	})
+
+	got := r.MarkSensitiveValues(v)
+
+	if got.GetAttr("name").IsMarked() {
+		t.Error("name is marked; should not be")
+	}
+	if !got.GetAttr("password").IsMarked() {
+		t.Error("password is not marked; should be")
+	}
+
+	netElem := got.GetAttr("network").Index(cty.NumberIntVal(0))
+	if netElem.GetAttr("cidr").IsMarked() {
+		t.Error("network.0.cidr is marked; should not be")
+	}
+	if !netElem.GetAttr("secret_key").IsMarked() {
+		t.Error("network.0.secret_key is not marked; should be")
+	}
+
+	// Sets cannot contain marked elements, so the mark must be promoted to
+	// the whole set.
+	if !got.GetAttr("credential").IsMarked() {
+		t.Error("credential is not marked; should be, since it contains a sensitive attribute")
+	}
+}
+
+func TestResourceMarkSensitiveValuesNull(t *testing.T) {
+	r := &Resource{
+		Schema: map[string]*Schema{
+			"password": {
+				Type:      TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+		},
+	}
+
+	ty := cty.Object(map[string]cty.Type{"password": cty.String})
+
+	if got := r.MarkSensitiveValues(cty.NullVal(ty)); !got.IsNull() {
+		t.Errorf("wrong result for null value: %#v", got)
+	}
+	if got := r.MarkSensitiveValues(cty.UnknownVal(ty)); got.IsKnown() {
+		t.Errorf("wrong result for unknown value: %#v", got)
+	}
+}
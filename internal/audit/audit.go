@@ -0,0 +1,70 @@
+// Package audit records the per-resource operations performed during an
+// apply as structured, append-only JSONL records, so that change-audit
+// requirements can be satisfied without scraping Terraform's human-oriented
+// CLI output.
+//
+// Records are written through a Sink, which either appends to a local file
+// or ships the records to an HTTP endpoint, depending on how the operation
+// was configured.
+package audit
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Record describes one resource operation performed during an apply.
+type Record struct {
+	// Time is when the operation completed.
+	Time time.Time `json:"time"`
+
+	// Address is the absolute address of the affected resource instance.
+	Address string `json:"address"`
+
+	// Action is the plan action that was applied, such as "create",
+	// "update", or "delete".
+	Action string `json:"action"`
+
+	// Provider is the provider type implied by the resource type. The hook
+	// API that produces these records is not told which provider
+	// configuration performed each operation, so this is the best
+	// identification available.
+	Provider string `json:"provider"`
+
+	// OldChecksum and NewChecksum are checksums of the resource instance
+	// object before and after the operation, in the form
+	// "sha256:<hex digest>". A checksum is empty when the corresponding
+	// object does not exist, such as the old object for a create or the
+	// new object for a destroy.
+	OldChecksum string `json:"old_checksum,omitempty"`
+	NewChecksum string `json:"new_checksum,omitempty"`
+
+	// DurationSeconds is the wall-clock duration of the operation.
+	DurationSeconds float64 `json:"duration_seconds"`
+
+	// Outcome is "success" or "error". Error holds the error message when
+	// the outcome is "error".
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+}
+
+// NewSink returns a sink appropriate for the given target: an HTTP sink if
+// the target is an http or https URL, and an appending file sink otherwise.
+func NewSink(target string) (Sink, error) {
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		return NewHTTPSink(target), nil
+	}
+	return NewFileSink(target)
+}
+
+// Checksum produces the checksum representation used in Record for the
+// given serialization of a resource instance object. An empty src produces
+// an empty checksum, representing an absent object.
+func Checksum(src []byte) string {
+	if len(src) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(src))
+}
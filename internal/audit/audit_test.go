@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileSinkAppends(t *testing.T) {
+	td, err := ioutil.TempDir("", "tf-audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+	path := filepath.Join(td, "audit.jsonl")
+
+	// Two separate sinks against the same path emulate two successive
+	// operations; the second must append rather than truncate.
+	for _, addr := range []string{"test_instance.a", "test_instance.b"} {
+		sink, err := NewFileSink(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = sink.WriteRecord(&Record{
+			Time:    time.Now(),
+			Address: addr,
+			Action:  "create",
+			Outcome: "success",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var addrs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("invalid JSON line %q: %s", scanner.Text(), err)
+		}
+		addrs = append(addrs, rec.Address)
+	}
+	if len(addrs) != 2 || addrs[0] != "test_instance.a" || addrs[1] != "test_instance.b" {
+		t.Errorf("wrong records in log: %#v", addrs)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	var body string
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		src, _ := ioutil.ReadAll(req.Body)
+		body = string(src)
+		contentType = req.Header.Get("Content-Type")
+	}))
+	defer server.Close()
+
+	sink := NewHTTPSink(server.URL)
+	sink.WriteRecord(&Record{Address: "test_instance.a", Action: "create", Outcome: "success"})
+	sink.WriteRecord(&Record{Address: "test_instance.b", Action: "delete", Outcome: "error"})
+	if body != "" {
+		t.Error("records shipped before Close")
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if contentType != "application/x-ndjson" {
+		t.Errorf("wrong content type %q", contentType)
+	}
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("wrong number of lines %d; want 2\n%s", len(lines), body)
+	}
+	var rec Record
+	if err := json.Unmarshal([]byte(lines[1]), &rec); err != nil {
+		t.Fatalf("invalid JSON line %q: %s", lines[1], err)
+	}
+	if rec.Address != "test_instance.b" || rec.Outcome != "error" {
+		t.Errorf("wrong record: %#v", rec)
+	}
+}
+
+func TestHTTPSinkServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink := NewHTTPSink(server.URL)
+	sink.WriteRecord(&Record{Address: "test_instance.a"})
+	if err := sink.Close(); err == nil {
+		t.Error("no error for server failure")
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	if got := Checksum(nil); got != "" {
+		t.Errorf("wrong checksum for absent object %q; want empty", got)
+	}
+	got := Checksum([]byte(`{"id":"foo"}`))
+	if !strings.HasPrefix(got, "sha256:") || len(got) != len("sha256:")+64 {
+		t.Errorf("malformed checksum %q", got)
+	}
+	if got != Checksum([]byte(`{"id":"foo"}`)) {
+		t.Error("checksum is not deterministic")
+	}
+}
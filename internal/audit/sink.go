@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Sink receives audit records as they are produced during an operation.
+// Implementations must be safe for concurrent use, since resource
+// operations run in parallel.
+type Sink interface {
+	// WriteRecord accepts one record. Implementations may buffer records
+	// until Close is called.
+	WriteRecord(rec *Record) error
+
+	// Close flushes any buffered records and releases resources held by
+	// the sink. No further records may be written after Close.
+	Close() error
+}
+
+// FileSink appends records to a local file, one JSON document per line.
+// The file is opened in append mode so that successive operations
+// accumulate into the same log.
+type FileSink struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+var _ Sink = (*FileSink)(nil)
+
+// NewFileSink opens (creating if necessary) the file at the given path for
+// appending.
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %s", path, err)
+	}
+	return &FileSink{f: f}, nil
+}
+
+func (s *FileSink) WriteRecord(rec *Record) error {
+	src, err := json.Marshal(rec)
+	if err != nil {
+		// Should never happen, since Record contains only simple types.
+		return fmt.Errorf("failed to serialize audit record: %s", err)
+	}
+	src = append(src, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.f.Write(src)
+	return err
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
+
+// HTTPSink buffers records for the duration of an operation and ships them
+// to an HTTP endpoint in a single POST request when closed, with one JSON
+// document per line in the request body.
+type HTTPSink struct {
+	mu  sync.Mutex
+	url string
+	buf bytes.Buffer
+
+	// client can be overridden in tests; nil means http.DefaultClient.
+	client *http.Client
+}
+
+var _ Sink = (*HTTPSink)(nil)
+
+// NewHTTPSink returns a sink that ships records to the given URL.
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{url: url}
+}
+
+func (s *HTTPSink) WriteRecord(rec *Record) error {
+	src, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to serialize audit record: %s", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.Write(src)
+	s.buf.WriteByte('\n')
+	return nil
+}
+
+func (s *HTTPSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.buf.Len() == 0 {
+		return nil
+	}
+
+	client := s.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Post(s.url, "application/x-ndjson", bytes.NewReader(s.buf.Bytes()))
+	if err != nil {
+		return fmt.Errorf("failed to ship audit records to %s: %s", s.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to ship audit records to %s: server returned %s", s.url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Package msgcat is a minimal message catalog for user-facing CLI strings,
+// allowing downstream distributions to ship translated output without
+// forking every format string.
+//
+// Messages are keyed by their original English text, in the style of
+// gettext, so call sites simply wrap their literals in T and behave
+// identically when no catalog is present:
+//
+//	c.Ui.Output(fmt.Sprintf(msgcat.T("Apply complete! Resources: %d added."), n))
+//
+// The active locale is selected once at startup from the TF_LANG environment
+// variable, falling back to the conventional LC_ALL, LC_MESSAGES, and LANG
+// variables in that order. Catalogs can be compiled in via Register, or
+// provided at runtime as JSON files named <locale>.json in the directory
+// named by the TF_MESSAGE_CATALOG_DIR environment variable, each containing
+// a single object mapping original strings to translations.
+package msgcat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// MessageCatalogDirEnvVar is the environment variable naming a directory of
+// <locale>.json catalog files to load at startup.
+const MessageCatalogDirEnvVar = "TF_MESSAGE_CATALOG_DIR"
+
+var (
+	mu       sync.RWMutex
+	locale   string // normalized active locale, e.g. "de_DE"; empty means untranslated
+	catalogs = map[string]map[string]string{}
+)
+
+func init() {
+	locale = normalizeLocale(localeFromEnv(os.Getenv))
+	if dir := os.Getenv(MessageCatalogDirEnvVar); dir != "" {
+		// A broken catalog directory must not prevent Terraform from
+		// running, so we just report the problem and continue untranslated.
+		if err := LoadDir(dir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to load message catalogs from %s: %s\n", dir, err)
+		}
+	}
+}
+
+// T returns the translation of the given message in the active locale, or
+// the message unchanged if there is no active locale or the catalog has no
+// entry for it. The message may be a fmt format string, in which case its
+// translation must use the same verbs in the same order.
+func T(msg string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if locale == "" {
+		return msg
+	}
+	// Try the full locale first and then just the language part, so a
+	// "de" catalog serves "de_DE" and "de_AT" alike.
+	for _, candidate := range localeCandidates(locale) {
+		if translated, ok := catalogs[candidate][msg]; ok && translated != "" {
+			return translated
+		}
+	}
+	return msg
+}
+
+// Register adds (or extends) the compiled-in catalog for the given locale.
+// It is intended to be called from an init function in a downstream
+// distribution's catalog file. Later registrations override earlier entries
+// for the same message.
+func Register(locale string, messages map[string]string) {
+	locale = normalizeLocale(locale)
+	if locale == "" {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	catalog := catalogs[locale]
+	if catalog == nil {
+		catalog = make(map[string]string, len(messages))
+		catalogs[locale] = catalog
+	}
+	for msg, translated := range messages {
+		catalog[msg] = translated
+	}
+}
+
+// LoadDir loads every <locale>.json file in the given directory as a catalog
+// for that locale, merging with any catalogs already registered. This is
+// called automatically at startup for the TF_MESSAGE_CATALOG_DIR directory.
+func LoadDir(dir string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		src, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(src, &messages); err != nil {
+			return fmt.Errorf("invalid message catalog %s: %s", name, err)
+		}
+		Register(strings.TrimSuffix(name, ".json"), messages)
+	}
+	return nil
+}
+
+// localeFromEnv returns the raw locale setting using the given lookup
+// function, honoring the conventional precedence of the POSIX locale
+// variables with TF_LANG as a Terraform-specific override.
+func localeFromEnv(getenv func(string) string) string {
+	for _, name := range []string{"TF_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// normalizeLocale reduces a raw locale setting such as "de_DE.UTF-8@euro"
+// to the "de_DE" form used as a catalog key. The "C" and "POSIX" locales
+// mean no translation at all, as does an empty setting.
+func normalizeLocale(raw string) string {
+	if i := strings.IndexAny(raw, ".@"); i >= 0 {
+		raw = raw[:i]
+	}
+	raw = strings.Replace(raw, "-", "_", -1)
+	if raw == "C" || raw == "POSIX" {
+		return ""
+	}
+	return raw
+}
+
+// localeCandidates returns the catalog keys to try for a normalized locale,
+// from most to least specific.
+func localeCandidates(locale string) []string {
+	if i := strings.Index(locale, "_"); i >= 0 {
+		return []string{locale, locale[:i]}
+	}
+	return []string{locale}
+}
@@ -0,0 +1,135 @@
+package msgcat
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setTestLocale forces the active locale for the duration of a test, since
+// the real locale is fixed at startup from the environment. It returns a
+// function to restore the previous locale, to be called via defer.
+func setTestLocale(name string) func() {
+	mu.Lock()
+	old := locale
+	locale = normalizeLocale(name)
+	mu.Unlock()
+	return func() {
+		mu.Lock()
+		locale = old
+		mu.Unlock()
+	}
+}
+
+func TestT(t *testing.T) {
+	Register("de", map[string]string{
+		"Error:": "Fehler:",
+	})
+	Register("de_DE", map[string]string{
+		"Warning:": "Warnung:",
+	})
+
+	defer setTestLocale("de_DE.UTF-8")()
+	if got, want := T("Warning:"), "Warnung:"; got != want {
+		t.Errorf("wrong translation %q; want %q", got, want)
+	}
+	// The language-only catalog serves regional locales too.
+	if got, want := T("Error:"), "Fehler:"; got != want {
+		t.Errorf("wrong translation %q; want %q", got, want)
+	}
+	// Messages with no catalog entry pass through unchanged.
+	if got, want := T("Apply complete!"), "Apply complete!"; got != want {
+		t.Errorf("wrong translation %q; want %q", got, want)
+	}
+
+	defer setTestLocale("")()
+	if got, want := T("Error:"), "Error:"; got != want {
+		t.Errorf("wrong untranslated result %q; want %q", got, want)
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf-msgcat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	err = ioutil.WriteFile(
+		filepath.Join(dir, "fr.json"),
+		[]byte(`{"Error:": "Erreur :"}`),
+		0644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadDir(dir); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defer setTestLocale("fr_FR")()
+	if got, want := T("Error:"), "Erreur :"; got != want {
+		t.Errorf("wrong translation %q; want %q", got, want)
+	}
+}
+
+func TestLoadDirInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tf-msgcat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "xx.json"), []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadDir(dir); err == nil {
+		t.Fatal("expected error for invalid catalog file")
+	}
+}
+
+func TestLocaleFromEnv(t *testing.T) {
+	env := map[string]string{
+		"LANG":        "fr_FR.UTF-8",
+		"LC_MESSAGES": "es_ES",
+		"TF_LANG":     "de_DE",
+	}
+	getenv := func(name string) string { return env[name] }
+
+	if got, want := localeFromEnv(getenv), "de_DE"; got != want {
+		t.Errorf("wrong locale %q; want %q", got, want)
+	}
+	delete(env, "TF_LANG")
+	if got, want := localeFromEnv(getenv), "es_ES"; got != want {
+		t.Errorf("wrong locale %q; want %q", got, want)
+	}
+	delete(env, "LC_MESSAGES")
+	if got, want := localeFromEnv(getenv), "fr_FR.UTF-8"; got != want {
+		t.Errorf("wrong locale %q; want %q", got, want)
+	}
+	delete(env, "LANG")
+	if got := localeFromEnv(getenv); got != "" {
+		t.Errorf("wrong locale %q; want empty", got)
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"C", ""},
+		{"POSIX", ""},
+		{"C.UTF-8", ""},
+		{"de", "de"},
+		{"de_DE", "de_DE"},
+		{"de-DE", "de_DE"},
+		{"de_DE.UTF-8", "de_DE"},
+		{"de_DE.UTF-8@euro", "de_DE"},
+	}
+	for _, test := range tests {
+		if got := normalizeLocale(test.input); got != test.want {
+			t.Errorf("normalizeLocale(%q) = %q; want %q", test.input, got, test.want)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package providercache
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -502,6 +504,145 @@ func (i *Installer) SelectedPackages() (map[addrs.Provider]*CachedProvider, erro
 	return ret, nil
 }
 
+// SelectDevPackage records an additional selection in the installer's lock
+// file for a provider package that was placed into the target directory by
+// some process other than EnsureProviderVersions, such as a development
+// build compiled directly from provider source code.
+//
+// The package for the given provider version must already be present in the
+// target directory. Because EnsureProviderVersions discards any selections
+// previously recorded in the lock file, callers must call this method only
+// after any call to EnsureProviderVersions for the same target directory.
+func (i *Installer) SelectDevPackage(provider addrs.Provider, version getproviders.Version) (*CachedProvider, error) {
+	cached := i.targetDir.ProviderVersion(provider, version)
+	if cached == nil {
+		return nil, fmt.Errorf("package for %s v%s is not present in the target directory", provider, version)
+	}
+
+	hash, err := cached.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate checksum for %s v%s package: %s", provider, version, err)
+	}
+
+	lock := i.lockFile()
+	entries, err := lock.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selections file: %s", err)
+	}
+	if entries == nil {
+		entries = map[addrs.Provider]lockFileEntry{}
+	}
+	entries[provider] = lockFileEntry{
+		SelectedVersion: version,
+		PackageHash:     hash,
+	}
+	if err := lock.Write(entries); err != nil {
+		return nil, fmt.Errorf("failed to record a manifest of selected providers: %s", err)
+	}
+
+	return cached, nil
+}
+
+// PrunedPackage describes one provider package removed by
+// PruneUnselectedPackages.
+type PrunedPackage struct {
+	Provider addrs.Provider
+	Version  getproviders.Version
+
+	// Size is the total size in bytes of the files that were removed.
+	// Packages that were only symlinks into a shared global cache free just
+	// the link itself, so their reported size can be zero.
+	Size int64
+}
+
+// PruneUnselectedPackages removes from the target directory any provider
+// packages that are not recorded as selected in the lock file, returning a
+// description of each package it removed. Long-lived working directories
+// otherwise accumulate one package per provider version ever installed.
+//
+// Because EnsureProviderVersions rewrites the lock file, callers should run
+// this only after a successful installation pass, so that the lock file
+// reflects the versions the configuration currently depends on.
+func (i *Installer) PruneUnselectedPackages() ([]PrunedPackage, error) {
+	entries, err := i.lockFile().Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selections file: %s", err)
+	}
+
+	var pruned []PrunedPackage
+	for provider, cacheds := range i.targetDir.AllAvailablePackages() {
+		for _, cached := range cacheds {
+			if entry, ok := entries[provider]; ok && entry.SelectedVersion == cached.Version {
+				continue
+			}
+			packageDir := filepath.FromSlash(cached.PackageDir)
+			size := packageDirSize(packageDir)
+			if err := os.RemoveAll(packageDir); err != nil {
+				return pruned, fmt.Errorf("failed to remove unused package for %s v%s: %s", provider, cached.Version, err)
+			}
+			pruned = append(pruned, PrunedPackage{
+				Provider: provider,
+				Version:  cached.Version,
+				Size:     size,
+			})
+		}
+	}
+
+	// Removing packages can leave behind empty version and namespace
+	// directories, which would otherwise confuse later scans of the cache.
+	pruneEmptyDirs(i.targetDir.baseDir)
+
+	// Invalidate the target directory's metadata cache so that subsequent
+	// read calls will re-scan to incorporate our changes.
+	i.targetDir.metaCache = nil
+
+	sort.Slice(pruned, func(a, b int) bool {
+		if pruned[a].Provider.String() != pruned[b].Provider.String() {
+			return pruned[a].Provider.String() < pruned[b].Provider.String()
+		}
+		return pruned[a].Version.LessThan(pruned[b].Version)
+	})
+	return pruned, nil
+}
+
+// packageDirSize returns the total size in bytes of the files under the
+// given directory, as a best effort; errors during scanning just cause the
+// affected files to be excluded from the total.
+func packageDirSize(dir string) int64 {
+	var size int64
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// pruneEmptyDirs removes, as a best effort, any directories under baseDir
+// that are left empty after pruning. baseDir itself is always preserved.
+func pruneEmptyDirs(baseDir string) {
+	var visit func(dir string) bool
+	visit = func(dir string) bool {
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return false
+		}
+		empty := true
+		for _, entry := range entries {
+			if entry.IsDir() && visit(filepath.Join(dir, entry.Name())) {
+				continue
+			}
+			empty = false
+		}
+		if !empty || dir == baseDir {
+			return false
+		}
+		return os.Remove(dir) == nil
+	}
+	visit(baseDir)
+}
+
 // InstallMode customizes the details of how an install operation treats
 // providers that have versions already cached in the target directory.
 type InstallMode rune
@@ -4,9 +4,12 @@ import (
 	"context"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/apparentlymart/go-versions/versions"
+
 	"github.com/hashicorp/terraform/addrs"
 	"github.com/hashicorp/terraform/internal/getproviders"
 )
@@ -98,3 +101,82 @@ func TestEnsureProviderVersions(t *testing.T) {
 		t.Fatalf("wrong error: %s", err)
 	}
 }
+
+func TestPruneUnselectedPackages(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "providercache-prune")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	platform := getproviders.Platform{OS: "linux", Arch: "amd64"}
+	nullProvider := addrs.NewProvider(addrs.DefaultRegistryHost, "hashicorp", "null")
+	randomProvider := addrs.NewProvider(addrs.DefaultRegistryHost, "hashicorp", "random")
+
+	fakePackage := func(provider addrs.Provider, version string) {
+		dir := filepath.Join(
+			tmpDir, provider.Hostname.ForDisplay(), provider.Namespace,
+			provider.Type, version, platform.String(),
+		)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		exe := filepath.Join(dir, "terraform-provider-"+provider.Type)
+		if err := ioutil.WriteFile(exe, []byte("# fake provider\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fakePackage(nullProvider, "2.0.0")
+	fakePackage(nullProvider, "2.1.0")
+	fakePackage(randomProvider, "1.0.0")
+
+	dir := NewDirWithPlatform(tmpDir, platform)
+	inst := NewInstaller(dir, nil)
+
+	// Only null 2.0.0 is selected, so everything else is unused.
+	err = inst.lockFile().Write(map[addrs.Provider]lockFileEntry{
+		nullProvider: {
+			SelectedVersion: versions.MustParseVersion("2.0.0"),
+			PackageHash:     "h1:placeholder",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pruned, err := inst.PruneUnselectedPackages()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, want := len(pruned), 2; got != want {
+		t.Fatalf("wrong number of pruned packages %d; want %d\n%#v", got, want, pruned)
+	}
+	if got, want := pruned[0].Provider, nullProvider; got != want {
+		t.Errorf("wrong first pruned provider %s; want %s", got, want)
+	}
+	if got, want := pruned[0].Version.String(), "2.1.0"; got != want {
+		t.Errorf("wrong first pruned version %s; want %s", got, want)
+	}
+	if got, want := pruned[1].Provider, randomProvider; got != want {
+		t.Errorf("wrong second pruned provider %s; want %s", got, want)
+	}
+	for _, p := range pruned {
+		if p.Size <= 0 {
+			t.Errorf("pruned package %s v%s has no recorded size", p.Provider, p.Version)
+		}
+	}
+
+	// The selected package must survive, and the pruned ones (including the
+	// now-empty directories above them) must be gone.
+	if got := dir.ProviderVersion(nullProvider, versions.MustParseVersion("2.0.0")); got == nil {
+		t.Error("selected null 2.0.0 package was removed")
+	}
+	if got := dir.ProviderVersion(nullProvider, versions.MustParseVersion("2.1.0")); got != nil {
+		t.Error("unselected null 2.1.0 package still present")
+	}
+	randomDir := filepath.Join(tmpDir, "registry.terraform.io", "hashicorp", "random")
+	if _, err := os.Stat(randomDir); !os.IsNotExist(err) {
+		t.Errorf("empty directory %s was not cleaned up", randomDir)
+	}
+}
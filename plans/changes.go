@@ -157,6 +157,12 @@ type ResourceInstanceChange struct {
 	// Terraform that relates to this change. Terraform will save this
 	// byte-for-byte and return it to the provider in the apply call.
 	Private []byte
+
+	// Description is an optional human-readable annotation for the resource
+	// this change applies to, taken from its configuration. It carries no
+	// meaning for the apply operation and is retained only so that UIs can
+	// show it alongside the rendered change.
+	Description string
 }
 
 // Encode produces a variant of the reciever that has its change values
@@ -174,6 +180,7 @@ func (rc *ResourceInstanceChange) Encode(ty cty.Type) (*ResourceInstanceChangeSr
 		ChangeSrc:       *cs,
 		RequiredReplace: rc.RequiredReplace,
 		Private:         rc.Private,
+		Description:     rc.Description,
 	}, err
 }
 
@@ -46,6 +46,12 @@ type ResourceInstanceChangeSrc struct {
 	// Terraform that relates to this change. Terraform will save this
 	// byte-for-byte and return it to the provider in the apply call.
 	Private []byte
+
+	// Description is an optional human-readable annotation for the resource
+	// this change applies to, taken from its configuration. It carries no
+	// meaning for the apply operation and is retained only so that UIs can
+	// show it alongside the rendered change.
+	Description string
 }
 
 // Decode unmarshals the raw representation of the instance object being
@@ -63,6 +69,7 @@ func (rcs *ResourceInstanceChangeSrc) Decode(ty cty.Type) (*ResourceInstanceChan
 		Change:          *change,
 		RequiredReplace: rcs.RequiredReplace,
 		Private:         rcs.Private,
+		Description:     rcs.Description,
 	}, nil
 }
 
@@ -366,7 +366,11 @@ type ResourceInstanceChange struct {
 	// An unordered set of paths that prompted the change action to be
 	// "replace" rather than "update". Empty for any action other than
 	// "replace".
-	RequiredReplace      []*Path  `protobuf:"bytes,11,rep,name=required_replace,json=requiredReplace,proto3" json:"required_replace,omitempty"`
+	RequiredReplace []*Path `protobuf:"bytes,11,rep,name=required_replace,json=requiredReplace,proto3" json:"required_replace,omitempty"`
+	// Optional human-readable annotation for the resource this change
+	// applies to, taken from its configuration. Purely informational;
+	// carries no meaning for the apply operation.
+	Description          string   `protobuf:"bytes,12,opt,name=description,proto3" json:"description,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -497,6 +501,13 @@ func (m *ResourceInstanceChange) GetRequiredReplace() []*Path {
 	return nil
 }
 
+func (m *ResourceInstanceChange) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*ResourceInstanceChange) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
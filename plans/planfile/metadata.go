@@ -0,0 +1,234 @@
+package planfile
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/configs/configload"
+	tfversion "github.com/hashicorp/terraform/version"
+)
+
+const tfmetaFilename = "tfmeta.json"
+
+// Metadata records provenance information about a plan file: who created
+// it, from what configuration, and with which version of Terraform.
+//
+// Unlike the plan itself, the metadata is advisory: it exists so that a
+// saved plan can be audited later and so that Terraform can warn or refuse
+// when a plan is applied against a configuration that has changed since
+// the plan was created. Plan files created by earlier versions of
+// Terraform have no metadata.
+type Metadata struct {
+	// CreatedAt is the time at which the plan file was created.
+	CreatedAt time.Time `json:"CreatedAt"`
+
+	// CreatedBy identifies who created the plan, as "username@hostname".
+	// Either portion may be absent if it could not be determined.
+	CreatedBy string `json:"CreatedBy,omitempty"`
+
+	// TerraformVersion is the version of Terraform that created the plan.
+	TerraformVersion string `json:"TerraformVersion"`
+
+	// Workspace is the name of the workspace the plan was created for.
+	Workspace string `json:"Workspace,omitempty"`
+
+	// GitCommit is the commit id of the working directory's git repository
+	// at the time the plan was created, if the configuration was in one.
+	GitCommit string `json:"GitCommit,omitempty"`
+
+	// ConfigHash is a hash of the configuration snapshot embedded in the
+	// plan file, as produced by ConfigSnapshotHash. Before applying the
+	// plan this can be compared against a hash of the current working
+	// directory's configuration to detect a stale plan.
+	ConfigHash string `json:"ConfigHash,omitempty"`
+}
+
+// newMetadata constructs the metadata to embed in a plan file created for
+// the given configuration snapshot and workspace. Everything here is
+// best-effort: fields that cannot be determined are left empty rather than
+// failing plan creation.
+func newMetadata(configSnap *configload.Snapshot, workspace string) *Metadata {
+	meta := &Metadata{
+		CreatedAt:        time.Now().UTC(),
+		TerraformVersion: tfversion.String(),
+		Workspace:        workspace,
+		ConfigHash:       ConfigSnapshotHash(configSnap),
+	}
+
+	var username, hostname string
+	if u, err := user.Current(); err == nil {
+		username = u.Username
+	}
+	if h, err := os.Hostname(); err == nil {
+		hostname = h
+	}
+	switch {
+	case username != "" && hostname != "":
+		meta.CreatedBy = username + "@" + hostname
+	case username != "":
+		meta.CreatedBy = username
+	case hostname != "":
+		meta.CreatedBy = "@" + hostname
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		meta.GitCommit = gitCommit(wd)
+	}
+
+	return meta
+}
+
+// ConfigSnapshotHash returns a hash covering all of the source files in the
+// given configuration snapshot, such that any change to the configuration
+// (in the root module or any descendent module) produces a different
+// result.
+//
+// The result is of the form "sha256:hex". It is stable for a given
+// snapshot regardless of the order the files were added in, so a snapshot
+// freshly taken from the working directory can be compared against the
+// hash recorded in a plan file's metadata.
+func ConfigSnapshotHash(snap *configload.Snapshot) string {
+	if snap == nil {
+		return ""
+	}
+
+	moduleKeys := make([]string, 0, len(snap.Modules))
+	for key := range snap.Modules {
+		moduleKeys = append(moduleKeys, key)
+	}
+	sort.Strings(moduleKeys)
+
+	h := sha256.New()
+	for _, moduleKey := range moduleKeys {
+		mod := snap.Modules[moduleKey]
+		fileNames := make([]string, 0, len(mod.Files))
+		for name := range mod.Files {
+			fileNames = append(fileNames, name)
+		}
+		sort.Strings(fileNames)
+
+		for _, name := range fileNames {
+			src := mod.Files[name]
+			fmt.Fprintf(h, "%s/%s:%d\n", moduleKey, name, len(src))
+			h.Write(src)
+		}
+	}
+
+	return fmt.Sprintf("sha256:%x", h.Sum(nil))
+}
+
+// gitCommit returns the commit id that HEAD refers to in the git repository
+// containing the given directory, or an empty string if the directory is
+// not in a git repository or the commit can't be determined.
+//
+// We read the repository metadata directly rather than running the git
+// command so that this works even when git is not installed.
+func gitCommit(dir string) string {
+	gitDir := ""
+	for current := dir; ; {
+		candidate := filepath.Join(current, ".git")
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			gitDir = candidate
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return ""
+		}
+		current = parent
+	}
+
+	head, err := ioutil.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	headStr := strings.TrimSpace(string(head))
+
+	if !strings.HasPrefix(headStr, "ref: ") {
+		// detached HEAD: the file holds the commit id directly
+		return headStr
+	}
+	refName := strings.TrimSpace(strings.TrimPrefix(headStr, "ref: "))
+
+	// loose ref file
+	if refData, err := ioutil.ReadFile(filepath.Join(gitDir, filepath.FromSlash(refName))); err == nil {
+		return strings.TrimSpace(string(refData))
+	}
+
+	// fall back to packed-refs
+	packed, err := ioutil.ReadFile(filepath.Join(gitDir, "packed-refs"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(packed), "\n") {
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "^") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == refName {
+			return fields[0]
+		}
+	}
+
+	return ""
+}
+
+func writeMetadata(meta *Metadata, zw *zip.Writer) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     tfmetaFilename,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	src, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// ReadMetadata reads the provenance metadata embedded in the plan file.
+//
+// If the plan file was created by a version of Terraform that did not
+// record metadata then the result is nil with no error, and the caller
+// must skip any verification that would rely on it.
+func (r *Reader) ReadMetadata() (*Metadata, error) {
+	for _, file := range r.zip.File {
+		if file.Name != tfmetaFilename {
+			continue
+		}
+
+		mr, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract metadata from plan file: %s", err)
+		}
+		defer mr.Close()
+
+		src, err := ioutil.ReadAll(mr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read metadata from plan file: %s", err)
+		}
+
+		meta := &Metadata{}
+		if err := json.Unmarshal(src, meta); err != nil {
+			return nil, fmt.Errorf("plan file metadata is invalid: %s", err)
+		}
+		return meta, nil
+	}
+
+	// Plan files from earlier versions have no metadata.
+	return nil, nil
+}
@@ -0,0 +1,43 @@
+package planfile
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configload"
+)
+
+func TestConfigSnapshotHash(t *testing.T) {
+	snap := &configload.Snapshot{
+		Modules: map[string]*configload.SnapshotModule{
+			"": {
+				Dir: ".",
+				Files: map[string][]byte{
+					"main.tf":    []byte(`resource "null_resource" "a" {}`),
+					"outputs.tf": []byte(`output "a" { value = "a" }`),
+				},
+			},
+			"child": {
+				Dir: ".terraform/modules/child",
+				Files: map[string][]byte{
+					"main.tf": []byte(`variable "a" {}`),
+				},
+			},
+		},
+	}
+
+	hash := ConfigSnapshotHash(snap)
+	if hash == "" {
+		t.Fatal("empty hash")
+	}
+
+	// hashing must be stable across calls for the same content
+	if again := ConfigSnapshotHash(snap); again != hash {
+		t.Fatalf("hash is not stable: %q then %q", hash, again)
+	}
+
+	// any change to any file in any module must change the hash
+	snap.Modules["child"].Files["main.tf"] = []byte(`variable "b" {}`)
+	if changed := ConfigSnapshotHash(snap); changed == hash {
+		t.Fatalf("hash did not change when a module source file changed")
+	}
+}
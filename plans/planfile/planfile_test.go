@@ -63,7 +63,12 @@ func TestRoundtrip(t *testing.T) {
 	}
 	planFn := filepath.Join(workDir, "tfplan")
 
-	err = Create(planFn, snapIn, stateFileIn, planIn)
+	timingsIn := []ResourceTiming{
+		{Address: "test_thing.a", Operation: "refresh", DurationSeconds: 0.25},
+		{Address: "test_thing.a", Operation: "plan", DurationSeconds: 1.5},
+	}
+
+	err = Create(planFn, snapIn, stateFileIn, planIn, timingsIn)
 	if err != nil {
 		t.Fatalf("failed to create plan file: %s", err)
 	}
@@ -112,4 +117,36 @@ func TestRoundtrip(t *testing.T) {
 			t.Errorf("when reading config: %s", diags.Err())
 		}
 	})
+
+	t.Run("ReadTimings", func(t *testing.T) {
+		timingsOut, err := pr.ReadTimings()
+		if err != nil {
+			t.Fatalf("failed to read timings: %s", err)
+		}
+		if !reflect.DeepEqual(timingsIn, timingsOut) {
+			t.Errorf("timings did not survive round-trip\nresult: %sinput: %s", spew.Sdump(timingsOut), spew.Sdump(timingsIn))
+		}
+	})
+
+	t.Run("ReadMetadata", func(t *testing.T) {
+		metaOut, err := pr.ReadMetadata()
+		if err != nil {
+			t.Fatalf("failed to read metadata: %s", err)
+		}
+		if metaOut == nil {
+			t.Fatal("no metadata in plan file")
+		}
+		if got, want := metaOut.TerraformVersion, tfversion.String(); got != want {
+			t.Errorf("wrong terraform version %q; want %q", got, want)
+		}
+		if got, want := metaOut.Workspace, "default"; got != want {
+			t.Errorf("wrong workspace %q; want %q", got, want)
+		}
+		if got, want := metaOut.ConfigHash, ConfigSnapshotHash(snapIn); got != want {
+			t.Errorf("wrong config hash %q; want %q", got, want)
+		}
+		if metaOut.CreatedAt.IsZero() {
+			t.Errorf("creation time not recorded")
+		}
+	})
 }
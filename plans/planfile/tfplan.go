@@ -201,6 +201,8 @@ func resourceChangeFromTfplan(rawChange *planproto.ResourceInstanceChange) (*pla
 		ret.Private = rawChange.Private
 	}
 
+	ret.Description = rawChange.Description
+
 	return ret, nil
 }
 
@@ -424,6 +426,8 @@ func resourceChangeToTfplan(change *plans.ResourceInstanceChangeSrc) (*planproto
 		ret.Private = change.Private
 	}
 
+	ret.Description = change.Description
+
 	return ret, nil
 }
 
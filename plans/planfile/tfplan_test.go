@@ -69,6 +69,7 @@ func TestTFPlanRoundTrip(t *testing.T) {
 							"id": cty.UnknownVal(cty.String),
 						}), objTy),
 					},
+					Description: "primary woot for the test module",
 				},
 				{
 					Addr: addrs.Resource{
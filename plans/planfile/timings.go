@@ -0,0 +1,82 @@
+package planfile
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+const tftimingsFilename = "tftimings.json"
+
+// ResourceTiming records the wall-clock duration of one provider operation
+// against one resource instance during the walk that created a plan.
+//
+// Timings are recorded in a plan file only when the plan was created with
+// timing collection enabled, and like the plan metadata they are advisory:
+// plan files without them are still valid.
+type ResourceTiming struct {
+	// Address is the absolute resource instance address the operation was
+	// performed against.
+	Address string `json:"address"`
+
+	// Operation is the kind of operation timed: "refresh", "plan" or
+	// "apply".
+	Operation string `json:"operation"`
+
+	// DurationSeconds is the wall-clock duration of the operation.
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// writeTimings adds a timings file to the given zip writer.
+func writeTimings(timings []ResourceTiming, zw *zip.Writer) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     tftimingsFilename,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create timings file: %s", err)
+	}
+
+	src, err := json.MarshalIndent(timings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize timings: %s", err)
+	}
+	if _, err := w.Write(src); err != nil {
+		return fmt.Errorf("failed to write timings: %s", err)
+	}
+	return nil
+}
+
+// ReadTimings reads the operation timings embedded in the plan file.
+//
+// It returns nil with no error if the plan file contains no timings, which
+// is the case both for plan files created by earlier versions of Terraform
+// and for plans created without timing collection enabled.
+func (r *Reader) ReadTimings() ([]ResourceTiming, error) {
+	for _, file := range r.zip.File {
+		if file.Name != tftimingsFilename {
+			continue
+		}
+
+		tr, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract timings from plan file: %s", err)
+		}
+		defer tr.Close()
+
+		src, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read timings from plan file: %s", err)
+		}
+
+		var timings []ResourceTiming
+		if err := json.Unmarshal(src, &timings); err != nil {
+			return nil, fmt.Errorf("invalid timings in plan file: %s", err)
+		}
+		return timings, nil
+	}
+	return nil, nil // plan file has no timings
+}
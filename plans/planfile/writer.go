@@ -18,7 +18,12 @@ import (
 // state file in addition to the plan itself, so that Terraform can detect
 // if the world has changed since the plan was created and thus refuse to
 // apply it.
-func Create(filename string, configSnap *configload.Snapshot, stateFile *statefile.File, plan *plans.Plan) error {
+//
+// timings optionally records the durations of the provider operations
+// performed during the planning walk, for later inclusion in the plan's
+// machine-readable rendering; it may be nil when timing collection wasn't
+// enabled.
+func Create(filename string, configSnap *configload.Snapshot, stateFile *statefile.File, plan *plans.Plan, timings []ResourceTiming) error {
 	f, err := os.Create(filename)
 	if err != nil {
 		return err
@@ -68,5 +73,21 @@ func Create(filename string, configSnap *configload.Snapshot, stateFile *statefi
 		}
 	}
 
+	// tfmeta file, recording the provenance of the plan
+	{
+		err := writeMetadata(newMetadata(configSnap, plan.Backend.Workspace), zw)
+		if err != nil {
+			return fmt.Errorf("failed to write plan metadata: %s", err)
+		}
+	}
+
+	// tftimings file, when operation timings were collected
+	if len(timings) > 0 {
+		err := writeTimings(timings, zw)
+		if err != nil {
+			return fmt.Errorf("failed to write plan timings: %s", err)
+		}
+	}
+
 	return nil
 }
@@ -384,3 +384,32 @@ type ReadDataSourceResponse struct {
 	// Diagnostics contains any warnings or errors from the method call.
 	Diagnostics tfdiags.Diagnostics
 }
+
+// CredentialsValidator is an optional extension interface that a provider
+// implementation may satisfy to support a quick pre-flight check of its
+// configured credentials.
+//
+// Terraform Core calls ValidateCredentials immediately after configuring a
+// provider during plan and apply operations, so that an expired or otherwise
+// unusable credential can fail fast with a clear diagnostic rather than
+// part-way through a potentially long graph walk. Implementations should
+// make at most one inexpensive remote call and must never create, modify,
+// or delete any remote objects.
+//
+// This check is not yet part of the provider wire protocol, so only
+// providers running in the same process as Terraform Core can currently
+// implement it.
+type CredentialsValidator interface {
+	// ValidateCredentials checks that the credentials the provider was
+	// configured with are currently usable.
+	ValidateCredentials(ValidateCredentialsRequest) ValidateCredentialsResponse
+}
+
+type ValidateCredentialsRequest struct {
+}
+
+type ValidateCredentialsResponse struct {
+	// Diagnostics contains any errors describing why the configured
+	// credentials cannot be used, along with any warnings about them.
+	Diagnostics tfdiags.Diagnostics
+}
@@ -100,5 +100,14 @@ func NewLockInfo() *LockInfo {
 // LockInfo is a deprecated lias for statemgr.LockInfo
 type LockInfo = statemgr.LockInfo
 
+// LockMode is a deprecated alias for statemgr.LockMode
+type LockMode = statemgr.LockMode
+
+// Deprecated aliases for the statemgr lock mode constants.
+const (
+	LockModeRead  = statemgr.LockModeRead
+	LockModeWrite = statemgr.LockModeWrite
+)
+
 // LockError is a deprecated alias for statemgr.LockError
 type LockError = statemgr.LockError
@@ -9,6 +9,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
@@ -40,6 +41,13 @@ type Filesystem struct {
 	// is a subsequent call to write a different state.
 	backupPath string
 
+	// backupDir and backupRetention configure timestamped backups as an
+	// alternative to the single file at backupPath: each backup is written
+	// to a new file in backupDir, and the oldest files are pruned so that
+	// at most backupRetention of them remain.
+	backupDir       string
+	backupRetention int
+
 	// the file handle corresponding to PathOut
 	stateFileOut *os.File
 
@@ -48,6 +56,10 @@ type Filesystem struct {
 	// implementation.
 	lockID string
 
+	// lockMode records the mode of the currently-held lock, so that Unlock
+	// knows whether any lock metadata was written.
+	lockMode LockMode
+
 	// created is set to true if stateFileOut didn't exist before we created it.
 	// This is mostly so we can clean up empty files during tests, but doesn't
 	// hurt to remove file we never wrote to.
@@ -108,6 +120,23 @@ func (s *Filesystem) BackupPath() string {
 	return s.backupPath
 }
 
+// SetBackupRetention configures the receiver to write its backup snapshots
+// as timestamped files in the given directory rather than to a single file
+// that each subsequent command overwrites, pruning the oldest files so that
+// at most "keep" of them remain. If keep is zero or negative then no pruning
+// is done and every backup is retained.
+//
+// This replaces any backup path previously set with SetBackupPath, and has
+// the same lifecycle: the next snapshot read becomes the backup candidate,
+// and one backup is written if a different snapshot is subsequently written.
+func (s *Filesystem) SetBackupRetention(dir string, keep int) {
+	s.backupPath = ""
+	s.backupDir = dir
+	s.backupRetention = keep
+	s.backupFile = nil
+	s.writtenBackup = false
+}
+
 // State is an implementation of Reader.
 func (s *Filesystem) State() *states.State {
 	defer s.mutex()()
@@ -146,20 +175,11 @@ func (s *Filesystem) writeState(state *states.State, meta *SnapshotMeta) error {
 
 	// We'll try to write our backup first, so we can be sure we've created
 	// it successfully before clobbering the original file it came from.
-	if !s.writtenBackup && s.backupFile != nil && s.backupPath != "" {
+	if !s.writtenBackup && s.backupFile != nil && (s.backupPath != "" || s.backupDir != "") {
 		if !statefile.StatesMarshalEqual(state, s.backupFile.State) {
-			log.Printf("[TRACE] statemgr.Filesystem: creating backup snapshot at %s", s.backupPath)
-			bfh, err := os.Create(s.backupPath)
-			if err != nil {
-				return fmt.Errorf("failed to create local state backup file: %s", err)
-			}
-			defer bfh.Close()
-
-			err = statefile.Write(s.backupFile, bfh)
-			if err != nil {
-				return fmt.Errorf("failed to write to local state backup file: %s", err)
+			if err := s.writeBackup(); err != nil {
+				return err
 			}
-
 			s.writtenBackup = true
 		} else {
 			log.Print("[TRACE] statemgr.Filesystem: not making a backup, because the new snapshot is identical to the old")
@@ -167,10 +187,10 @@ func (s *Filesystem) writeState(state *states.State, meta *SnapshotMeta) error {
 	} else {
 		// This branch is all just logging, to help understand why we didn't make a backup.
 		switch {
-		case s.backupPath == "":
+		case s.backupPath == "" && s.backupDir == "":
 			log.Print("[TRACE] statemgr.Filesystem: state file backups are disabled")
 		case s.writtenBackup:
-			log.Printf("[TRACE] statemgr.Filesystem: have already backed up original %s to %s on a previous write", s.path, s.backupPath)
+			log.Printf("[TRACE] statemgr.Filesystem: have already backed up original %s on a previous write", s.path)
 		case s.backupFile == nil:
 			log.Printf("[TRACE] statemgr.Filesystem: no original state snapshot to back up")
 		default:
@@ -221,6 +241,77 @@ func (s *Filesystem) writeState(state *states.State, meta *SnapshotMeta) error {
 	return nil
 }
 
+// writeBackup writes the previously-read snapshot to either the single
+// backup path or, if a retention policy is configured, to a new timestamped
+// file in the backup directory followed by a prune of the oldest files.
+func (s *Filesystem) writeBackup() error {
+	backupPath := s.backupPath
+	if s.backupDir != "" {
+		if err := os.MkdirAll(s.backupDir, 0755); err != nil {
+			return fmt.Errorf("failed to create local state backup directory: %s", err)
+		}
+		backupPath = filepath.Join(s.backupDir, s.backupFilename(time.Now().UTC()))
+	}
+
+	log.Printf("[TRACE] statemgr.Filesystem: creating backup snapshot at %s", backupPath)
+	bfh, err := os.Create(backupPath)
+	if err != nil {
+		return fmt.Errorf("failed to create local state backup file: %s", err)
+	}
+	defer bfh.Close()
+
+	if err := statefile.Write(s.backupFile, bfh); err != nil {
+		return fmt.Errorf("failed to write to local state backup file: %s", err)
+	}
+
+	if s.backupDir != "" {
+		if err := s.pruneBackups(); err != nil {
+			// Pruning failure doesn't endanger the state itself, so we just
+			// log it rather than failing the write.
+			log.Printf("[WARN] statemgr.Filesystem: failed to prune old state backups in %s: %s", s.backupDir, err)
+		}
+	}
+
+	return nil
+}
+
+// backupFilename returns the name for a timestamped backup file created at
+// the given time. The timestamp format sorts lexically in creation order,
+// which pruneBackups relies on.
+func (s *Filesystem) backupFilename(t time.Time) string {
+	_, stateName := filepath.Split(s.path)
+	return fmt.Sprintf("%s.%s.backup", stateName, t.Format("20060102T150405.000000000Z"))
+}
+
+// pruneBackups removes the oldest timestamped backup files for this state
+// from the backup directory, keeping the newest backupRetention of them. A
+// non-positive retention keeps everything.
+func (s *Filesystem) pruneBackups() error {
+	if s.backupRetention <= 0 {
+		return nil
+	}
+
+	_, stateName := filepath.Split(s.path)
+	pattern := filepath.Join(s.backupDir, stateName+".*.backup")
+	backups, err := filepath.Glob(pattern)
+	if err != nil {
+		return err
+	}
+
+	// The timestamped filenames sort lexically in creation order, so the
+	// files to remove are at the front of the sorted list.
+	sort.Strings(backups)
+	for len(backups) > s.backupRetention {
+		log.Printf("[TRACE] statemgr.Filesystem: pruning old backup snapshot %s", backups[0])
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+
+	return nil
+}
+
 // PersistState is an implementation of Persister that does nothing because
 // this type's Writer implementation does its own persistence.
 func (s *Filesystem) PersistState() error {
@@ -306,7 +397,7 @@ func (s *Filesystem) Lock(info *LockInfo) (string, error) {
 		return "", fmt.Errorf("state %q already locked", s.stateFileOut.Name())
 	}
 
-	if err := s.lock(); err != nil {
+	if err := s.lock(info.LockMode()); err != nil {
 		info, infoErr := s.lockInfo()
 		if infoErr != nil {
 			err = multierror.Append(err, infoErr)
@@ -321,6 +412,15 @@ func (s *Filesystem) Lock(info *LockInfo) (string, error) {
 	}
 
 	s.lockID = info.ID
+	s.lockMode = info.LockMode()
+
+	if s.lockMode == LockModeRead {
+		// Shared read locks rely only on the OS-level lock for exclusion
+		// against writers. We don't record lock metadata, since concurrent
+		// readers would otherwise overwrite each other's info file.
+		return s.lockID, nil
+	}
+
 	return s.lockID, s.writeLockInfo(info)
 }
 
@@ -345,9 +445,12 @@ func (s *Filesystem) Unlock(id string) error {
 		}
 	}
 
-	lockInfoPath := s.lockInfoPath()
-	log.Printf("[TRACE] statemgr.Filesystem: removing lock metadata file %s", lockInfoPath)
-	os.Remove(lockInfoPath)
+	if s.lockMode != LockModeRead {
+		// read locks don't write lock metadata, so there's nothing to remove
+		lockInfoPath := s.lockInfoPath()
+		log.Printf("[TRACE] statemgr.Filesystem: removing lock metadata file %s", lockInfoPath)
+		os.Remove(lockInfoPath)
+	}
 
 	fileName := s.stateFileOut.Name()
 
@@ -356,6 +459,7 @@ func (s *Filesystem) Unlock(id string) error {
 	s.stateFileOut.Close()
 	s.stateFileOut = nil
 	s.lockID = ""
+	s.lockMode = ""
 
 	// clean up the state file if we created it an never wrote to it
 	stat, err := os.Stat(fileName)
@@ -10,10 +10,16 @@ import (
 
 // use fcntl POSIX locks for the most consistent behavior across platforms, and
 // hopefully some campatibility over NFS and CIFS.
-func (s *Filesystem) lock() error {
-	log.Printf("[TRACE] statemgr.Filesystem: locking %s using fcntl flock", s.path)
+func (s *Filesystem) lock(mode LockMode) error {
+	log.Printf("[TRACE] statemgr.Filesystem: taking %s lock on %s using fcntl flock", mode, s.path)
+	lockType := int16(syscall.F_WRLCK)
+	if mode == LockModeRead {
+		// fcntl read locks are shared, so concurrent readers don't conflict
+		// with each other but do conflict with a write lock.
+		lockType = syscall.F_RDLCK
+	}
 	flock := &syscall.Flock_t{
-		Type:   syscall.F_RDLCK | syscall.F_WRLCK,
+		Type:   lockType,
 		Whence: int16(os.SEEK_SET),
 		Start:  0,
 		Len:    0,
@@ -22,8 +22,8 @@ const (
 	_LOCKFILE_EXCLUSIVE_LOCK   = 2
 )
 
-func (s *Filesystem) lock() error {
-	log.Printf("[TRACE] statemgr.Filesystem: locking %s using LockFileEx", s.path)
+func (s *Filesystem) lock(mode LockMode) error {
+	log.Printf("[TRACE] statemgr.Filesystem: taking %s lock on %s using LockFileEx", mode, s.path)
 
 	// even though we're failing immediately, an overlapped event structure is
 	// required
@@ -33,9 +33,16 @@ func (s *Filesystem) lock() error {
 	}
 	defer syscall.CloseHandle(ol.HEvent)
 
+	// Without _LOCKFILE_EXCLUSIVE_LOCK this requests a shared lock, which
+	// may be held by any number of readers at once.
+	flags := uint32(_LOCKFILE_FAIL_IMMEDIATELY)
+	if mode != LockModeRead {
+		flags |= _LOCKFILE_EXCLUSIVE_LOCK
+	}
+
 	return lockFileEx(
 		syscall.Handle(s.stateFileOut.Fd()),
-		_LOCKFILE_EXCLUSIVE_LOCK|_LOCKFILE_FAIL_IMMEDIATELY,
+		flags,
 		0,              // reserved
 		0,              // bytes low
 		math.MaxUint32, // bytes high
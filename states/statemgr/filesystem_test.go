@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -103,6 +104,81 @@ func TestFilesystemLocks(t *testing.T) {
 	}
 }
 
+func TestFilesystemReadLocks(t *testing.T) {
+	// The child processes spawned below read the state file with the real
+	// Terraform version, so unlike the other lock tests we must write the
+	// snapshot with that version rather than overriding it.
+	f, err := ioutil.TempFile("", "tf")
+	if err != nil {
+		t.Fatalf("failed to create temporary file %s", err)
+	}
+	err = statefile.Write(&statefile.File{
+		Lineage:          "test-lineage",
+		Serial:           0,
+		TerraformVersion: tfversion.SemVer,
+		State:            TestFullInitialState(),
+	}, f)
+	if err != nil {
+		t.Fatalf("failed to write initial state to %s: %s", f.Name(), err)
+	}
+	f.Close()
+
+	s := NewFilesystem(f.Name())
+	if err := s.RefreshState(); err != nil {
+		t.Fatalf("initial refresh failed: %s", err)
+	}
+	defer os.Remove(s.readPath)
+
+	// take a shared read lock first
+	info := NewLockInfo()
+	info.Operation = "test"
+	info.Mode = LockModeRead
+	lockID, err := s.Lock(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// another process can take a read lock at the same time
+	out, err := exec.Command("go", "run", "-mod=vendor", "testdata/lockstate.go", s.path, "read").CombinedOutput()
+	if err != nil {
+		t.Fatal("unexpected read lock failure", err, string(out))
+	}
+	if strings.Contains(string(out), "lock failed") {
+		t.Fatal("read lock should be shared with another read lock, got", string(out))
+	}
+
+	// but a write lock conflicts with the read lock we hold
+	out, err = exec.Command("go", "run", "-mod=vendor", "testdata/lockstate.go", s.path).CombinedOutput()
+	if err != nil {
+		t.Fatal("unexpected lock failure", err, string(out))
+	}
+	if !strings.Contains(string(out), "lock failed") {
+		t.Fatal("expected write lock to conflict with read lock, got", string(out))
+	}
+
+	// read locks don't write lock metadata
+	lockInfoPath := s.lockInfoPath()
+	if _, err := os.Stat(lockInfoPath); !os.IsNotExist(err) {
+		t.Fatal("read lock should not write lock info")
+	}
+
+	if err := s.Unlock(lockID); err != nil {
+		t.Fatal(err)
+	}
+
+	// with the read lock released, a write lock can be taken
+	info = NewLockInfo()
+	info.Operation = "test"
+	lockID, err = s.Lock(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Unlock(lockID); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // Verify that we can write to the state file, as Windows' mandatory locking
 // will prevent writing to a handle different than the one that hold the lock.
 func TestFilesystem_writeWhileLocked(t *testing.T) {
@@ -177,6 +253,71 @@ func TestFilesystem_backup(t *testing.T) {
 	}
 }
 
+func TestFilesystem_backupRetention(t *testing.T) {
+	defer testOverrideVersion(t, "1.2.3")()
+
+	ls := testFilesystem(t)
+	statePath := ls.path
+	defer os.Remove(statePath)
+
+	backupDir, err := ioutil.TempDir("", "tf-backups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(backupDir)
+
+	// stateForRound returns a distinct state for each round, so that every
+	// write is a change that triggers a backup.
+	stateForRound := func(round int) *states.State {
+		state := TestFullInitialState()
+		state.RootModule().SetOutputValue("round", cty.NumberIntVal(int64(round)), false)
+		return state
+	}
+
+	// Each round creates a fresh manager for the same state path, the way
+	// each successive Terraform command would, so each one writes a backup
+	// of the state it found.
+	const rounds = 4
+	const keep = 2
+	for i := 0; i < rounds; i++ {
+		s := NewFilesystem(statePath)
+		s.SetBackupRetention(backupDir, keep)
+		if err := s.RefreshState(); err != nil {
+			t.Fatalf("round %d: refresh failed: %s", i, err)
+		}
+		if err := s.WriteState(stateForRound(i)); err != nil {
+			t.Fatalf("round %d: write failed: %s", i, err)
+		}
+	}
+
+	backups, err := filepath.Glob(filepath.Join(backupDir, "*.backup"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != keep {
+		t.Fatalf("wrong number of backups %d; want %d\n%s", len(backups), keep, strings.Join(backups, "\n"))
+	}
+
+	// The newest remaining backup must hold the state from the next-to-last
+	// round, since the final round backed up the state the previous one wrote.
+	sort.Strings(backups)
+	bfh, err := os.Open(backups[len(backups)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bfh.Close()
+	bf, err := statefile.Read(bfh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantState := stateForRound(rounds - 2)
+	if !bf.State.Equal(wantState) {
+		for _, problem := range deep.Equal(wantState, bf.State) {
+			t.Error(problem)
+		}
+	}
+}
+
 // This test verifies a particularly tricky behavior where the input file
 // is overridden and backups are enabled at the same time. This combination
 // requires special care because we must ensure that when we create a backup
@@ -50,6 +50,11 @@ type Locker interface {
 	// an instance of LockError immediately if the lock is already held,
 	// and the helper function LockWithContext uses this to automatically
 	// retry lock acquisition periodically until a timeout is reached.
+	//
+	// The given lock info may request a particular lock mode via its Mode
+	// field. Implementations that support shared locks may allow several
+	// read locks to be held at once, while implementations that do not
+	// must treat every lock as exclusive.
 	Lock(info *LockInfo) (string, error)
 
 	// Unlock releases a lock previously acquired by Lock.
@@ -70,6 +75,15 @@ var postLockHook func()
 // This method has a built-in retry/backoff behavior up to the context's
 // timeout.
 func LockWithContext(ctx context.Context, s Locker, info *LockInfo) (string, error) {
+	return LockWithContextNotify(ctx, s, info, nil)
+}
+
+// LockWithContextNotify is like LockWithContext, but additionally calls the
+// given callback with the current holder's lock info after each failed
+// attempt, so the caller can tell the user who is holding the lock while we
+// wait in line for it. A nil callback is allowed, making this equivalent to
+// LockWithContext.
+func LockWithContextNotify(ctx context.Context, s Locker, info *LockInfo, notify func(existing *LockInfo)) (string, error) {
 	delay := time.Second
 	maxDelay := 16 * time.Second
 	for {
@@ -90,16 +104,24 @@ func LockWithContext(ctx context.Context, s Locker, info *LockInfo) (string, err
 			return "", err
 		}
 
+		if notify != nil {
+			notify(le.Info)
+		}
+
 		if postLockHook != nil {
 			postLockHook()
 		}
 
+		// Add some random jitter to the delay so that clients queueing for
+		// the same lock don't all retry in lock-step.
+		wait := delay + time.Duration(rngSource.Int63n(int64(delay)/2+1))
+
 		// there's an existing lock, wait and try again
 		select {
 		case <-ctx.Done():
 			// return the last lock error with the info
 			return "", err
-		case <-time.After(delay):
+		case <-time.After(wait):
 			if delay < maxDelay {
 				delay *= 2
 			}
@@ -107,6 +129,27 @@ func LockWithContext(ctx context.Context, s Locker, info *LockInfo) (string, err
 	}
 }
 
+// LockMode distinguishes locks that must be exclusive from locks that may
+// be shared with other holders of the same mode.
+type LockMode string
+
+const (
+	// LockModeWrite is an exclusive lock, held by operations that may modify
+	// the state. This is the default mode when none is specified, matching
+	// the behavior of callers and lock implementations that predate lock
+	// modes.
+	LockModeWrite LockMode = "write"
+
+	// LockModeRead is a shared lock, held by operations that only read the
+	// state. Any number of read locks may be held at once, but a read lock
+	// cannot be acquired while a write lock is held, or vice-versa.
+	//
+	// Locker implementations that don't support shared locks treat read
+	// locks as write locks, which is always safe because it is strictly
+	// more conservative.
+	LockModeRead LockMode = "read"
+)
+
 // LockInfo stores lock metadata.
 //
 // Only Operation and Info are required to be set by the caller of Lock.
@@ -121,6 +164,12 @@ type LockInfo struct {
 	// Terraform operation, provided by the caller.
 	Operation string
 
+	// Mode is the requested lock mode. An empty Mode is equivalent to
+	// LockModeWrite; use the LockMode method to read this field so that
+	// lock info recorded by older versions of Terraform is handled
+	// correctly.
+	Mode LockMode `json:",omitempty"`
+
 	// Extra information to store with the lock, provided by the caller.
 	Info string
 
@@ -168,6 +217,23 @@ func NewLockInfo() *LockInfo {
 	return info
 }
 
+// LockMode returns the requested lock mode, treating an unset Mode as
+// LockModeWrite for compatibility with lock info recorded before lock
+// modes existed.
+func (l *LockInfo) LockMode() LockMode {
+	if l.Mode == "" {
+		return LockModeWrite
+	}
+	return l.Mode
+}
+
+// SharedWith returns true if a lock with the receiver's mode may be held
+// at the same time as a lock with the other given mode. Only read locks
+// may be shared.
+func (l *LockInfo) SharedWith(other *LockInfo) bool {
+	return l.LockMode() == LockModeRead && other.LockMode() == LockModeRead
+}
+
 // Err returns the lock info formatted in an error
 func (l *LockInfo) Err() error {
 	return errors.New(l.String())
@@ -188,6 +254,7 @@ func (l *LockInfo) String() string {
   ID:        {{.ID}}
   Path:      {{.Path}}
   Operation: {{.Operation}}
+  Mode:      {{.LockMode}}
   Who:       {{.Who}}
   Version:   {{.Version}}
   Created:   {{.Created}}
@@ -45,6 +45,7 @@ type fakeFull struct {
 
 	lockLock sync.Mutex
 	locked   bool
+	lockInfo *LockInfo
 }
 
 var _ Full = (*fakeFull)(nil)
@@ -70,13 +71,15 @@ func (m *fakeFull) Lock(info *LockInfo) (string, error) {
 	defer m.lockLock.Unlock()
 
 	if m.locked {
+		// report the holder's info, as a real backend would
 		return "", &LockError{
 			Err:  errors.New("fake state manager is locked"),
-			Info: info,
+			Info: m.lockInfo,
 		}
 	}
 
 	m.locked = true
+	m.lockInfo = info
 	return "placeholder", nil
 }
 
@@ -92,5 +95,6 @@ func (m *fakeFull) Unlock(id string) error {
 	}
 
 	m.locked = false
+	m.lockInfo = nil
 	return nil
 }
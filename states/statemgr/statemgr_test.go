@@ -89,6 +89,58 @@ func TestLockWithContext(t *testing.T) {
 	<-unlocked
 }
 
+func TestLockWithContextNotify(t *testing.T) {
+	s := NewFullFake(nil, TestFullInitialState())
+
+	holder := NewLockInfo()
+	holder.Who = "someone@somewhere"
+	holder.Operation = "apply"
+	id, err := s.Lock(holder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// unlock the state once the waiter has made a first attempt
+	attempted := make(chan struct{})
+	postLockHook = func() {
+		close(attempted)
+		postLockHook = nil
+	}
+	unlocked := make(chan struct{})
+	go func() {
+		defer close(unlocked)
+		<-attempted
+		if err := s.Unlock(id); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	var notified []*LockInfo
+	notify := func(existing *LockInfo) {
+		notified = append(notified, existing)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	info := NewLockInfo()
+	info.Info = "lock with context notify"
+	if _, err := LockWithContextNotify(ctx, s, info, notify); err != nil {
+		t.Fatal("lock should have completed within 5s:", err)
+	}
+	<-unlocked
+
+	if len(notified) == 0 {
+		t.Fatal("notify callback was never called")
+	}
+	if got, want := notified[0].Who, "someone@somewhere"; got != want {
+		t.Errorf("wrong holder %q reported; want %q", got, want)
+	}
+	if got, want := notified[0].Operation, "apply"; got != want {
+		t.Errorf("wrong operation %q reported; want %q", got, want)
+	}
+}
+
 func TestMain(m *testing.M) {
 	flag.Parse()
 	if testing.Verbose() {
@@ -8,11 +8,12 @@ import (
 	"github.com/hashicorp/terraform/states/statemgr"
 )
 
-// Attempt to open and lock a terraform state file.
+// Attempt to open and lock a terraform state file, optionally with the
+// lock mode given as a second argument.
 // Lock failure exits with 0 and writes "lock failed" to stderr.
 func main() {
-	if len(os.Args) != 2 {
-		log.Fatal(os.Args[0], "statefile")
+	if len(os.Args) != 2 && len(os.Args) != 3 {
+		log.Fatal(os.Args[0], "statefile [mode]")
 	}
 
 	s := statemgr.NewFilesystem(os.Args[1])
@@ -20,6 +21,9 @@ func main() {
 	info := statemgr.NewLockInfo()
 	info.Operation = "test"
 	info.Info = "state locker"
+	if len(os.Args) == 3 {
+		info.Mode = statemgr.LockMode(os.Args[2])
+	}
 
 	_, err := s.Lock(info)
 	if err != nil {
@@ -24,6 +24,37 @@ import (
 	"github.com/hashicorp/terraform/tfdiags"
 )
 
+func TestContext2Plan_credentialsCheckFailed(t *testing.T) {
+	m := testModule(t, "plan-good")
+	p := testProvider("aws")
+	p.DiffFn = testDiffFn
+	var credsDiags tfdiags.Diagnostics
+	credsDiags = credsDiags.Append(errors.New("credentials have expired"))
+	p.ValidateCredentialsResponse = providers.ValidateCredentialsResponse{
+		Diagnostics: credsDiags,
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Config: m,
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("aws"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan()
+	if !diags.HasErrors() {
+		t.Fatal("plan succeeded; want credentials error")
+	}
+	if !p.ValidateCredentialsCalled {
+		t.Fatal("ValidateCredentials was not called")
+	}
+	if got, want := diags.Err().Error(), "credentials have expired"; !strings.Contains(got, want) {
+		t.Fatalf("wrong error %q; want %q", got, want)
+	}
+	if p.PlanResourceChangeCalled {
+		t.Fatal("provider was asked to plan changes despite the failed credentials check")
+	}
+}
+
 func TestContext2Plan_basic(t *testing.T) {
 	m := testModule(t, "plan-good")
 	p := testProvider("aws")
@@ -777,7 +777,7 @@ func contextOptsForPlanViaFile(configSnap *configload.Snapshot, state *states.St
 	}
 
 	filename := filepath.Join(dir, "tfplan")
-	err = planfile.Create(filename, configSnap, stateFile, plan)
+	err = planfile.Create(filename, configSnap, stateFile, plan, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -483,6 +483,7 @@ func (n *EvalDiff) Eval(ctx EvalContext) (interface{}, error) {
 				After:  plannedNewVal,
 			},
 			RequiredReplace: reqRep,
+			Description:     config.Description,
 		}
 	}
 
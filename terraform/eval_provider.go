@@ -52,6 +52,10 @@ type EvalConfigProvider struct {
 	Addr     addrs.AbsProviderConfig
 	Provider *providers.Interface
 	Config   *configs.Provider
+
+	// KeyData provides the each.key and each.value values when this is one
+	// instance of a provider block using for_each.
+	KeyData InstanceKeyEvalData
 }
 
 func (n *EvalConfigProvider) Eval(ctx EvalContext) (interface{}, error) {
@@ -72,7 +76,7 @@ func (n *EvalConfigProvider) Eval(ctx EvalContext) (interface{}, error) {
 	}
 
 	configSchema := resp.Provider.Block
-	configVal, configBody, evalDiags := ctx.EvaluateBlock(configBody, configSchema, nil, EvalDataForNoInstanceKey)
+	configVal, configBody, evalDiags := ctx.EvaluateBlock(configBody, configSchema, nil, n.KeyData)
 	diags = diags.Append(evalDiags)
 	if evalDiags.HasErrors() {
 		return nil, diags.NonFatalErr()
@@ -80,8 +84,21 @@ func (n *EvalConfigProvider) Eval(ctx EvalContext) (interface{}, error) {
 
 	configDiags := ctx.ConfigureProvider(n.Addr, configVal)
 	configDiags = configDiags.InConfigBody(configBody)
+	if configDiags.HasErrors() {
+		return nil, configDiags.ErrWithWarnings()
+	}
+	diags = diags.Append(configDiags)
+
+	// If the provider supports a pre-flight credentials check then we'll run
+	// it now, so that stale or expired credentials fail quickly with a clear
+	// diagnostic rather than part-way through the graph walk.
+	if validator, ok := provider.(providers.CredentialsValidator); ok {
+		log.Printf("[TRACE] EvalConfigProvider: pre-flight credentials check for %s", n.Addr)
+		resp := validator.ValidateCredentials(providers.ValidateCredentialsRequest{})
+		diags = diags.Append(resp.Diagnostics.InConfigBody(configBody))
+	}
 
-	return nil, configDiags.ErrWithWarnings()
+	return nil, diags.ErrWithWarnings()
 }
 
 // EvalInitProvider is an EvalNode implementation that initializes a provider
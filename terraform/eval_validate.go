@@ -70,6 +70,10 @@ type EvalValidateProvider struct {
 	Addr     addrs.AbsProviderConfig
 	Provider *providers.Interface
 	Config   *configs.Provider
+
+	// KeyData provides the each.key and each.value values when this is one
+	// instance of a provider block using for_each.
+	KeyData InstanceKeyEvalData
 }
 
 func (n *EvalValidateProvider) Eval(ctx EvalContext) (interface{}, error) {
@@ -92,7 +96,7 @@ func (n *EvalValidateProvider) Eval(ctx EvalContext) (interface{}, error) {
 		configSchema = &configschema.Block{}
 	}
 
-	configVal, configBody, evalDiags := ctx.EvaluateBlock(configBody, configSchema, nil, EvalDataForNoInstanceKey)
+	configVal, configBody, evalDiags := ctx.EvaluateBlock(configBody, configSchema, nil, n.KeyData)
 	diags = diags.Append(evalDiags)
 	if evalDiags.HasErrors() {
 		return nil, diags.NonFatalErr()
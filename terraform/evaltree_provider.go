@@ -1,6 +1,8 @@
 package terraform
 
 import (
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/hashicorp/terraform/addrs"
 	"github.com/hashicorp/terraform/configs"
 	"github.com/hashicorp/terraform/providers"
@@ -13,6 +15,17 @@ func ProviderEvalTree(n *NodeApplyableProvider, config *configs.Provider) EvalNo
 
 	addr := n.Addr
 
+	// If this node is one instance of a provider block with for_each then
+	// we expose its key and value as each.key and each.value while
+	// evaluating the configuration body.
+	keyData := EvalDataForNoInstanceKey
+	if n.EachKey != cty.NilVal {
+		keyData = InstanceKeyEvalData{
+			EachKey:   n.EachKey,
+			EachValue: n.EachValue,
+		}
+	}
+
 	seq := make([]EvalNode, 0, 5)
 	seq = append(seq, &EvalInitProvider{
 		Addr: addr,
@@ -43,6 +56,7 @@ func ProviderEvalTree(n *NodeApplyableProvider, config *configs.Provider) EvalNo
 					Addr:     addr,
 					Provider: &provider,
 					Config:   config,
+					KeyData:  keyData,
 				},
 			},
 		},
@@ -71,6 +85,7 @@ func ProviderEvalTree(n *NodeApplyableProvider, config *configs.Provider) EvalNo
 					Addr:     addr,
 					Provider: &provider,
 					Config:   config,
+					KeyData:  keyData,
 				},
 			},
 		},
@@ -178,6 +178,10 @@ func (b *PlanGraphBuilder) Steps() []GraphTransformer {
 		&CloseRootModuleTransformer{},
 	}
 
+	// Run any externally-registered transformers once the built-in steps
+	// have produced the full graph. (Experimental; see transform_external.go.)
+	steps = append(steps, externalTransformSteps()...)
+
 	if !b.DisableReduce {
 		// Perform the transitive reduction to make our graph a bit
 		// more sane if possible (it usually is possible).
@@ -1,6 +1,8 @@
 package terraform
 
 import (
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/hashicorp/terraform/addrs"
 	"github.com/hashicorp/terraform/configs"
 	"github.com/hashicorp/terraform/configs/configschema"
@@ -17,6 +19,12 @@ type ConcreteProviderNodeFunc func(*NodeAbstractProvider) dag.Vertex
 type NodeAbstractProvider struct {
 	Addr addrs.AbsProviderConfig
 
+	// EachKey and EachValue are set only for a provider configuration that
+	// is one instance of a provider block using for_each, and are exposed
+	// as each.key and each.value while evaluating the configuration body.
+	EachKey   cty.Value
+	EachValue cty.Value
+
 	// The fields below will be automatically set using the Attach
 	// interfaces if you're running those transforms, but also be explicitly
 	// set if you already have that information.
@@ -14,6 +14,7 @@ import (
 )
 
 var _ providers.Interface = (*MockProvider)(nil)
+var _ providers.CredentialsValidator = (*MockProvider)(nil)
 
 // MockProvider implements providers.Interface but mocks out all the
 // calls for testing purposes.
@@ -54,6 +55,10 @@ type MockProvider struct {
 	ConfigureRequest  providers.ConfigureRequest
 	ConfigureNewFn    func(providers.ConfigureRequest) providers.ConfigureResponse // Named ConfigureNewFn so we can still have the legacy ConfigureFn declared below
 
+	ValidateCredentialsCalled   bool
+	ValidateCredentialsResponse providers.ValidateCredentialsResponse
+	ValidateCredentialsFn       func(providers.ValidateCredentialsRequest) providers.ValidateCredentialsResponse
+
 	StopCalled   bool
 	StopFn       func() error
 	StopResponse error
@@ -253,6 +258,19 @@ func (p *MockProvider) Configure(r providers.ConfigureRequest) providers.Configu
 	return p.ConfigureResponse
 }
 
+func (p *MockProvider) ValidateCredentials(r providers.ValidateCredentialsRequest) providers.ValidateCredentialsResponse {
+	p.Lock()
+	defer p.Unlock()
+
+	p.ValidateCredentialsCalled = true
+
+	if p.ValidateCredentialsFn != nil {
+		return p.ValidateCredentialsFn(r)
+	}
+
+	return p.ValidateCredentialsResponse
+}
+
 func (p *MockProvider) Stop() error {
 	// We intentionally don't lock in this one because the whole point of this
 	// method is to be called concurrently with another operation that can
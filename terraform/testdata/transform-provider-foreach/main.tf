@@ -0,0 +1,16 @@
+provider "aws" {
+  for_each = {
+    east = "us-east-1"
+    west = "us-west-2"
+  }
+
+  region = each.value
+}
+
+resource "aws_instance" "east" {
+  provider = aws["east"]
+}
+
+resource "aws_instance" "west" {
+  provider = aws["west"]
+}
@@ -0,0 +1,88 @@
+package terraform
+
+import (
+	"fmt"
+	"log"
+	"sync"
+)
+
+// ExternalGraphTransformer is the interface implemented by externally-
+// registered graph transformers. It is intentionally a minimal subset of
+// GraphTransformer so that implementations outside this package depend only
+// on the graph types and not on the details of the built-in transformers.
+//
+// This extension point is experimental: the set of vertices and edges an
+// external transformer will see for a given configuration is not covered by
+// compatibility promises and may change between releases.
+type ExternalGraphTransformer interface {
+	// Name returns a short identifier for the transformer, used in logs and
+	// in error diagnostics when a transformation produces an invalid graph.
+	Name() string
+
+	// Transform is called with the graph after all of the built-in
+	// transformers for an operation have run, and may add additional edges
+	// (or vertices) to constrain ordering. It must not remove or replace
+	// vertices created by the built-in transformers.
+	Transform(*Graph) error
+}
+
+// RegisterGraphTransformer registers an ExternalGraphTransformer to run at
+// the end of graph construction for both plan and apply operations.
+//
+// Registration is global and is expected to happen during init, before any
+// graph is built; registering while a walk is in progress leads to undefined
+// behavior. This mechanism is experimental.
+func RegisterGraphTransformer(t ExternalGraphTransformer) {
+	externalTransformers.Lock()
+	defer externalTransformers.Unlock()
+	externalTransformers.transformers = append(externalTransformers.transformers, t)
+}
+
+var externalTransformers struct {
+	sync.Mutex
+	transformers []ExternalGraphTransformer
+}
+
+// externalTransformer adapts a registered ExternalGraphTransformer to the
+// internal GraphTransformer interface, validating the graph after each
+// external transformation so that a misbehaving extension is reported by
+// name rather than surfacing later as a confusing walk failure.
+type externalTransformer struct {
+	External ExternalGraphTransformer
+}
+
+func (t *externalTransformer) Transform(g *Graph) error {
+	before := len(g.Vertices())
+
+	log.Printf("[TRACE] externalTransformer: running external graph transformer %q", t.External.Name())
+	if err := t.External.Transform(g); err != nil {
+		return fmt.Errorf("external graph transformer %q failed: %s", t.External.Name(), err)
+	}
+
+	// External transformers may add constraints but must not remove the
+	// vertices the built-in transformers produced, since later steps and the
+	// graph walk itself rely on them being present.
+	if after := len(g.Vertices()); after < before {
+		return fmt.Errorf("external graph transformer %q removed vertices from the graph, which is not permitted", t.External.Name())
+	}
+
+	if err := g.Validate(); err != nil {
+		return fmt.Errorf("external graph transformer %q produced an invalid graph: %s", t.External.Name(), err)
+	}
+
+	return nil
+}
+
+// externalTransformSteps returns GraphTransformer steps for all of the
+// registered external transformers, in registration order. It returns an
+// empty slice in the common case where no extensions are registered.
+func externalTransformSteps() []GraphTransformer {
+	externalTransformers.Lock()
+	defer externalTransformers.Unlock()
+
+	steps := make([]GraphTransformer, 0, len(externalTransformers.transformers))
+	for _, ext := range externalTransformers.transformers {
+		steps = append(steps, &externalTransformer{External: ext})
+	}
+	return steps
+}
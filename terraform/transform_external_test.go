@@ -0,0 +1,81 @@
+package terraform
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/dag"
+)
+
+type testExternalTransformer struct {
+	name string
+	f    func(*Graph) error
+}
+
+func (t *testExternalTransformer) Name() string             { return t.name }
+func (t *testExternalTransformer) Transform(g *Graph) error { return t.f(g) }
+
+func TestExternalTransformer(t *testing.T) {
+	g := Graph{Path: addrs.RootModuleInstance}
+	g.Add("a")
+	g.Add("b")
+
+	tf := &externalTransformer{
+		External: &testExternalTransformer{
+			name: "ordering",
+			f: func(g *Graph) error {
+				g.Connect(dag.BasicEdge("a", "b"))
+				return nil
+			},
+		},
+	}
+	if err := tf.Transform(&g); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !g.HasEdge(dag.BasicEdge("a", "b")) {
+		t.Fatal("expected edge a => b")
+	}
+}
+
+func TestExternalTransformer_error(t *testing.T) {
+	g := Graph{Path: addrs.RootModuleInstance}
+	g.Add("a")
+
+	tf := &externalTransformer{
+		External: &testExternalTransformer{
+			name: "broken",
+			f: func(g *Graph) error {
+				return errors.New("nope")
+			},
+		},
+	}
+	err := tf.Transform(&g)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), `"broken"`) {
+		t.Fatalf("error does not name the transformer: %s", err)
+	}
+}
+
+func TestExternalTransformer_removesVertices(t *testing.T) {
+	g := Graph{Path: addrs.RootModuleInstance}
+	g.Add("a")
+	g.Add("b")
+
+	tf := &externalTransformer{
+		External: &testExternalTransformer{
+			name: "destructive",
+			f: func(g *Graph) error {
+				g.Remove("b")
+				return nil
+			},
+		},
+	}
+	if err := tf.Transform(&g); err == nil {
+		t.Fatal("expected error for removed vertex")
+	}
+}
@@ -3,11 +3,15 @@ package terraform
 import (
 	"fmt"
 	"log"
+	"sort"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/hashicorp/terraform/addrs"
 	"github.com/hashicorp/terraform/configs"
 	"github.com/hashicorp/terraform/dag"
+	"github.com/hashicorp/terraform/lang"
 	"github.com/hashicorp/terraform/tfdiags"
 )
 
@@ -594,6 +598,17 @@ func (t *ProviderConfigTransformer) transformSingle(g *Graph, c *configs.Config)
 	// add all providers from the configuration
 	for _, p := range mod.ProviderConfigs {
 		fqn := mod.ProviderForLocalConfig(p.Addr())
+
+		if p.ForEach != nil {
+			// A provider block with for_each expands to one configuration
+			// per key, each addressed by its key as if the key were an
+			// alias.
+			if err := t.expandProviderForEach(g, p, fqn, path); err != nil {
+				return err
+			}
+			continue
+		}
+
 		addr := addrs.AbsProviderConfig{
 			Provider: fqn,
 			Alias:    p.Alias,
@@ -630,6 +645,99 @@ func (t *ProviderConfigTransformer) transformSingle(g *Graph, c *configs.Config)
 	return t.addProxyProviders(g, c)
 }
 
+// expandProviderForEach adds one provider node per element of the for_each
+// expression in the given provider block. The keys stand in for aliases in
+// the instance addresses, so configurations and module calls refer to the
+// instances using index syntax, e.g. aws["west"].
+func (t *ProviderConfigTransformer) expandProviderForEach(g *Graph, p *configs.Provider, fqn addrs.Provider, path addrs.Module) error {
+	instances, err := evaluateProviderForEach(p)
+	if err != nil {
+		return err
+	}
+
+	// Sort the keys so that the graph nodes are created in a predictable
+	// order for logging and tests.
+	keys := make([]string, 0, len(instances))
+	for k := range instances {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		addr := addrs.AbsProviderConfig{
+			Provider: fqn,
+			Alias:    k,
+			Module:   path,
+		}
+
+		abstract := &NodeAbstractProvider{
+			Addr:      addr,
+			EachKey:   cty.StringVal(k),
+			EachValue: instances[k],
+		}
+		var v dag.Vertex
+		if t.Concrete != nil {
+			v = t.Concrete(abstract)
+		} else {
+			v = abstract
+		}
+
+		g.Add(v)
+		key := addr.String()
+		t.providers[key] = v.(GraphNodeProvider)
+		// Instances of a for_each provider block always carry configuration,
+		// so they can't act as proxies for configurations passed in from a
+		// parent module.
+		t.proxiable[key] = false
+	}
+
+	return nil
+}
+
+// evaluateProviderForEach evaluates the for_each expression of a provider
+// block, returning one element per instance to create.
+//
+// Provider configurations must be expanded before any expression evaluation
+// context exists, so for now the for_each value must be a constant map,
+// object, or set of strings, with no references to values elsewhere in the
+// configuration.
+func evaluateProviderForEach(p *configs.Provider) (map[string]cty.Value, error) {
+	hclCtx := &hcl.EvalContext{
+		Functions: (&lang.Scope{}).Functions(),
+	}
+	val, diags := p.ForEach.Value(hclCtx)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf(
+			"invalid for_each argument for provider %q block at %s: the for_each value must be a constant map or set of strings, with no references to other values: %s",
+			p.Name, p.DeclRange, diags.Error(),
+		)
+	}
+	if val.IsNull() || !val.IsWhollyKnown() {
+		return nil, fmt.Errorf(
+			"invalid for_each argument for provider %q block at %s: the for_each value must be a known, non-null map or set of strings",
+			p.Name, p.DeclRange,
+		)
+	}
+
+	ret := make(map[string]cty.Value)
+	switch {
+	case val.Type().IsMapType() || val.Type().IsObjectType():
+		for k, v := range val.AsValueMap() {
+			ret[k] = v
+		}
+	case val.Type().IsSetType() && val.Type().ElementType() == cty.String:
+		for _, v := range val.AsValueSlice() {
+			ret[v.AsString()] = v
+		}
+	default:
+		return nil, fmt.Errorf(
+			"invalid for_each argument for provider %q block at %s: the for_each value must be a map or set of strings, not %s",
+			p.Name, p.DeclRange, val.Type().FriendlyName(),
+		)
+	}
+	return ret, nil
+}
+
 func (t *ProviderConfigTransformer) addProxyProviders(g *Graph, c *configs.Config) error {
 	path := c.Path
 
@@ -729,12 +837,21 @@ func (t *ProviderConfigTransformer) attachProviderConfigs(g *Graph) error {
 		}
 
 		// Go through the provider configs to find the matching config
+		var match *configs.Provider
 		for _, p := range mc.Module.ProviderConfigs {
 			if p.Name == addr.Provider.Type && p.Alias == addr.Alias {
-				log.Printf("[TRACE] ProviderConfigTransformer: attaching to %q provider configuration from %s", dag.VertexName(v), p.DeclRange)
-				apn.AttachProvider(p)
+				match = p
 				break
 			}
+			// Instances of a for_each provider block are addressed by their
+			// keys in place of an alias, so they all share the one block.
+			if match == nil && p.ForEach != nil && p.Name == addr.Provider.Type {
+				match = p
+			}
+		}
+		if match != nil {
+			log.Printf("[TRACE] ProviderConfigTransformer: attaching to %q provider configuration from %s", dag.VertexName(v), match.DeclRange)
+			apn.AttachProvider(match)
 		}
 	}
 
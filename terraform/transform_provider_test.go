@@ -5,6 +5,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/hashicorp/terraform/addrs"
 	"github.com/hashicorp/terraform/dag"
 )
@@ -468,6 +470,58 @@ provider["registry.terraform.io/hashicorp/aws"].foo`)
 	}
 }
 
+// a provider block with for_each expands into one configuration per key,
+// each addressed by its key as if the key were an alias
+func TestProviderConfigTransformer_forEach(t *testing.T) {
+	mod := testModule(t, "transform-provider-foreach")
+	concrete := func(a *NodeAbstractProvider) dag.Vertex { return a }
+
+	g := Graph{Path: addrs.RootModuleInstance}
+	{
+		tf := &ConfigTransformer{Config: mod}
+		if err := tf.Transform(&g); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+	{
+		tf := &AttachResourceConfigTransformer{Config: mod}
+		if err := tf.Transform(&g); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+	{
+		tf := TransformProviders([]string{"aws"}, concrete, mod)
+		if err := tf.Transform(&g); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	actual := strings.TrimSpace(g.String())
+	expected := strings.TrimSpace(`aws_instance.east
+  provider["registry.terraform.io/hashicorp/aws"].east
+aws_instance.west
+  provider["registry.terraform.io/hashicorp/aws"].west
+provider["registry.terraform.io/hashicorp/aws"].east
+provider["registry.terraform.io/hashicorp/aws"].west`)
+	if actual != expected {
+		t.Fatalf("wrong result\n\nexpected:\n%s\n\ngot:\n%s", expected, actual)
+	}
+
+	for _, v := range g.Vertices() {
+		pn, ok := v.(*NodeAbstractProvider)
+		if !ok {
+			continue
+		}
+		alias := pn.Addr.Alias
+		if got, want := pn.EachKey, cty.StringVal(alias); !want.RawEquals(got) {
+			t.Errorf("wrong EachKey for %s: got %#v, want %#v", pn.Name(), got, want)
+		}
+		if pn.Config == nil {
+			t.Errorf("no config attached to %s", pn.Name())
+		}
+	}
+}
+
 // error out when a non-existent provider is named in a module providers map
 func TestProviderConfigTransformer_invalidProvider(t *testing.T) {
 	mod := testModule(t, "transform-provider-invalid")